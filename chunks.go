@@ -0,0 +1,109 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "errors"
+
+// RenderChunks Render the rows produced by source, holding no more than
+// size rows at a time
+// source is called twice and must emit the same rows each time: first to
+// size the columns, then to print the rows chunk by chunk, so that huge
+// tables are rendered without keeping all their rows. SetRowFilter and
+// numeric alignment apply as in Render, the filter receiving the index of
+// the row among those emitted. Settings that need all rows at once, such
+// as sorting, grouping, auto merge, transposing, hiding columns and
+// SetMaxRows, are ignored. The table must have no rows appended. It returns
+// the error reported by Err.
+func (t *Table) RenderChunks(size int, source func(emit func(row []string))) error {
+	defer t.lock()()
+	if len(t.rows) > 0 {
+		return errors.New("cannot render chunks of a table with rows")
+	}
+	if size < 1 {
+		size = 1
+	}
+	t.startHeatRanges()
+	numeric := make(map[int]bool)
+	sniff := func() {
+		for y, align := range sniffAlignment(t.rows) {
+			was, seen := numeric[y]
+			numeric[y] = align == ALIGN_RIGHT && (was || !seen)
+		}
+	}
+
+	// Size the columns, take the ranges of the heat maps and detect the
+	// numeric columns.
+	n := 0
+	source(func(row []string) {
+		if t.append(row) {
+			last := t.rows[len(t.rows)-1]
+			t.widenConditional(last)
+			if t.keep(last, n) {
+				t.widenHeatRanges(last)
+			}
+			n++
+		}
+		if len(t.lines) >= size {
+			sniff()
+			t.dropRows()
+		}
+	})
+	sniff()
+	t.dropRows()
+	t.numericCols = make(map[int]bool)
+	if t.numAlignHeader || t.numAlignRows || t.numAlignFooter {
+		t.numericCols = numeric
+	}
+	t.applyFixedWidths()
+	defer t.fitted()()
+
+	// Keep every chunk to the widths of the whole table.
+	fixed := t.fixedWidths
+	t.fixedWidths = copyIntMap(t.cs)
+	defer func() { t.fixedWidths = fixed }()
+
+	defer t.cacheColor()()
+	t.printTop()
+	printed, n := 0, 0
+	flush := func() {
+		t.applyFixedWidths()
+		for i := range t.lines {
+			if !t.keep(t.rows[i], n+i) {
+				continue
+			}
+			if t.rowLine && printed > 0 {
+				t.printRowLine(false)
+			}
+			t.rowPos = printed
+			t.printRow(t.lines[i], i)
+			printed++
+		}
+		n += len(t.lines)
+		t.dropRows()
+	}
+	source(func(row []string) {
+		t.append(row)
+		if len(t.lines) >= size {
+			flush()
+		}
+	})
+	flush()
+	if t.rowLine || t.borders.Bottom {
+		t.printRowLine(true)
+	}
+	t.printBottom()
+	return t.err
+}
+
+// dropRows clears the rows along with their heights.
+func (t *Table) dropRows() {
+	for i := range t.lines {
+		delete(t.rs, i)
+	}
+	t.clearRows()
+}
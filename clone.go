@@ -0,0 +1,217 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Clone returns a copy of the table writing to writer and sharing no state
+// with the table, so that a configured table can serve as a template for
+// many tables, possibly rendered by different goroutines. Settings and the
+// header are always copied; rows, the footer and the footnotes marked in
+// them only if rows is set. The copy is not rendered live, see SetLive.
+func (t *Table) Clone(writer io.Writer, rows bool) *Table {
+	defer t.lock()()
+	c := *t
+	c.out = writer
+	c.live, c.liveLines = false, 0
+	if t.mu != nil {
+		c.mu = &sync.Mutex{}
+	}
+
+	c.syms = append([]string(nil), t.syms...)
+	c.headers = copyLines(t.headers)
+	c.footers = copyLines(t.footers)
+	c.rawHeaders = append([]string(nil), t.rawHeaders...)
+	c.rawFooters = append([]string(nil), t.rawFooters...)
+	c.computed = append([]computedColumn(nil), t.computed...)
+	c.subtotalCols = append([]int(nil), t.subtotalCols...)
+	c.sortKeys = append([]SortKey(nil), t.sortKeys...)
+	c.visibleCols = append([]string(nil), t.visibleCols...)
+	c.headerParams = append([]string(nil), t.headerParams...)
+	c.columnsParams = append([]string(nil), t.columnsParams...)
+	c.footerParams = append([]string(nil), t.footerParams...)
+	c.columnsAlign = append([]int(nil), t.columnsAlign...)
+	c.footnotes = append([]string(nil), t.footnotes...)
+	c.headerGroups = append([]HeaderGroup(nil), t.headerGroups...)
+	c.groups, c.rowPerm, c.numericCols, c.treePrefixes = nil, nil, nil, nil
+
+	c.cs = copyIntMap(t.cs)
+	c.rs = copyIntMap(t.rs)
+	c.minWidths = copyIntMap(t.minWidths)
+	c.fixedWidths = copyIntMap(t.fixedWidths)
+	c.shrinkPriority = copyIntMap(t.shrinkPriority)
+	c.treeDepths = copyIntMap(t.treeDepths)
+	c.columnsToAutoMergeCells = copyBoolMap(t.columnsToAutoMergeCells)
+	c.noMergeColumns = copyBoolMap(t.noMergeColumns)
+	c.keepSpaceColumns = copyBoolMap(t.keepSpaceColumns)
+	c.hiddenCols = copyBoolMap(t.hiddenCols)
+	c.separators = copyBoolMap(t.separators)
+	c.noSeparators = copyBoolMap(t.noSeparators)
+	c.colMergeCompare = make(map[int]func(a, b string) bool)
+	for k, v := range t.colMergeCompare {
+		c.colMergeCompare[k] = v
+	}
+	c.colFormatters = make(map[int]func(interface{}) string)
+	for k, v := range t.colFormatters {
+		c.colFormatters[k] = v
+	}
+	c.numberFormats = make(map[int]numberFormat)
+	for k, v := range t.numberFormats {
+		c.numberFormats[k] = v
+	}
+	c.colIcons = make(map[int]map[string]string)
+	for k, v := range t.colIcons {
+		icons := make(map[string]string, len(v))
+		for value, icon := range v {
+			icons[value] = icon
+		}
+		c.colIcons[k] = icons
+	}
+	c.colTimeLayouts = make(map[int]string)
+	for k, v := range t.colTimeLayouts {
+		c.colTimeLayouts[k] = v
+	}
+	c.colEllipsis = make(map[int]string)
+	for k, v := range t.colEllipsis {
+		c.colEllipsis[k] = v
+	}
+	c.heatMaps = make(map[int][]Colors)
+	for k, v := range t.heatMaps {
+		c.heatMaps[k] = append([]Colors(nil), v...)
+	}
+	c.heatRanges = nil
+	c.condMinWidths = make(map[int][]conditionalWidth)
+	for k, v := range t.condMinWidths {
+		c.condMinWidths[k] = append([]conditionalWidth(nil), v...)
+	}
+	c.sectionSyms = make(map[int][]string)
+	for k, v := range t.sectionSyms {
+		c.sectionSyms[k] = v
+	}
+	c.nestedCells = make(map[[2]int]bool)
+	for k, v := range t.nestedCells {
+		c.nestedCells[k] = v
+	}
+	c.rowColors = make(map[int][]Colors)
+	for k, v := range t.rowColors {
+		c.rowColors[k] = append([]Colors(nil), v...)
+	}
+	c.cellStyles = make(map[int][]Cell)
+	for k, v := range t.cellStyles {
+		c.cellStyles[k] = append([]Cell(nil), v...)
+	}
+	c.dividers = make(map[int][]rowDivider)
+	for k, v := range t.dividers {
+		c.dividers[k] = append([]rowDivider(nil), v...)
+	}
+	c.rows = make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		c.rows[i] = append([]string(nil), row...)
+	}
+	c.lines = make([][][]string, len(t.lines))
+	for i, line := range t.lines {
+		c.lines[i] = copyLines(line)
+	}
+
+	if !rows {
+		c.clearRows()
+		c.clearFooter()
+		c.footnotes = c.headerFootnotes()
+		c.err = nil
+		// Size the columns by the header alone.
+		c.reparse()
+	}
+	return &c
+}
+
+// headerFootnotes returns the footnotes up to the last one marked in the
+// title, the caption, the header groups or the header, so that the markers
+// left keep their numbers.
+func (t *Table) headerFootnotes() []string {
+	texts := append([]string{t.title, t.captionText}, t.rawHeaders...)
+	for _, g := range t.headerGroups {
+		texts = append(texts, g.Title)
+	}
+	n := 0
+	for i := range t.footnotes {
+		marker := fmt.Sprintf("[%d]", i+1)
+		for _, text := range texts {
+			if strings.Contains(text, marker) {
+				n = i + 1
+				break
+			}
+		}
+	}
+	return t.footnotes[:n:n]
+}
+
+// derived returns a new table with the settings of t that hold whatever
+// its columns and rows, for the tables t is rendered through, such as the
+// projected and transposed ones. Settings of columns and rows are left to
+// the caller to carry over.
+func (t *Table) derived() *Table {
+	p := NewWriter(t.out)
+	p.caption, p.captionText, p.captionPos, p.captionAlign = t.caption, t.captionText, t.captionPos, t.captionAlign
+	p.title, p.titleAlign, p.titleParams, p.footnotes = t.title, t.titleAlign, t.titleParams, t.footnotes
+	p.autoFmt, p.autoWrap, p.reflowText, p.mW = t.autoFmt, t.autoWrap, t.reflowText, t.mW
+	p.syms, p.sectionSyms = t.syms, t.sectionSyms
+	p.pCenter, p.pRow, p.pColumn, p.tColumn, p.tRow = t.pCenter, t.pRow, t.pColumn, t.tColumn, t.tRow
+	p.hAlign, p.fAlign, p.align, p.newLine = t.hAlign, t.fAlign, t.align, t.newLine
+	p.rowLine, p.hdrLine, p.borders, p.borderParams = t.rowLine, t.hdrLine, t.borders, t.borderParams
+	p.noWhiteSpace, p.tablePadding = t.noWhiteSpace, t.tablePadding
+	p.autoMergeCells, p.mergeCompare = t.autoMergeCells, t.mergeCompare
+	p.strict, p.strictMapKeys, p.sanitizer = t.strict, t.strictMapKeys, t.sanitizer
+	p.timeLayout, p.now = t.timeLayout, t.now
+	p.numAlignHeader, p.numAlignRows, p.numAlignFooter = t.numAlignHeader, t.numAlignRows, t.numAlignFooter
+	p.groupTotal = t.groupTotal
+	p.stripes, p.cellCallback, p.cellHook = t.stripes, t.cellCallback, t.cellHook
+	p.colorMode, p.colorCached, p.colorOn = t.colorMode, t.colorCached, t.colorOn
+	p.maxWidth, p.autoTermWidth, p.maxRows = t.maxWidth, t.autoTermWidth, t.maxRows
+	p.hMaxLines, p.fMaxLines, p.maxLines, p.lineEllipsis = t.hMaxLines, t.fMaxLines, t.maxLines, t.lineEllipsis
+	p.verticalHeader, p.rtl, p.tabWidth = t.verticalHeader, t.rtl, t.tabWidth
+	p.truncatePos, p.ellipsis = t.truncatePos, t.ellipsis
+	p.workers = t.workers
+	p.term = t.term
+	return p
+}
+
+// copyLines returns a copy of the lines of the cells of a row.
+func copyLines(cells [][]string) [][]string {
+	if cells == nil {
+		return nil
+	}
+	out := make([][]string, len(cells))
+	for i, lines := range cells {
+		out[i] = append([]string(nil), lines...)
+	}
+	return out
+}
+
+func copyIntMap(m map[int]int) map[int]int {
+	out := make(map[int]int, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func copyBoolMap(m map[int]bool) map[int]bool {
+	if m == nil {
+		return nil
+	}
+	out := make(map[int]bool, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -11,8 +11,24 @@ import (
 	"encoding/csv"
 	"io"
 	"os"
+	"strings"
 )
 
+// CSVOptions configures NewCSVWithOptions
+type CSVOptions struct {
+	// Comma is the field delimiter, ',' when zero.
+	Comma rune
+	// Comment starts lines that are skipped, if not zero.
+	Comment rune
+	// HasHeader uses the first record as the table header.
+	HasHeader bool
+	// MaxRows stops reading after this many data rows, if greater than zero.
+	MaxRows int
+	// SniffTypes right aligns columns whose values are all numbers and left
+	// aligns the others.
+	SniffTypes bool
+}
+
 // NewCSV Start A new table by importing from a CSV file
 // Takes io.Writer and csv File name
 func NewCSV(writer io.Writer, fileName string, hasHeader bool) (*Table, error) {
@@ -31,22 +47,68 @@ func NewCSV(writer io.Writer, fileName string, hasHeader bool) (*Table, error) {
 // See http://golang.org/src/pkg/encoding/csv/reader.go?s=3213:3671#L94
 func NewCSVReader(writer io.Writer, csvReader *csv.Reader, hasHeader bool) (*Table, error) {
 	t := NewWriter(writer)
+	if err := t.readCSV(csvReader, hasHeader, 0); err != nil {
+		return &Table{}, err
+	}
+	return t, nil
+}
+
+// NewCSVWithOptions Start a New Table Writer from CSV data read from reader
+// This allows previewing large files with MaxRows without reading them whole.
+func NewCSVWithOptions(writer io.Writer, reader io.Reader, opts CSVOptions) (*Table, error) {
+	csvReader := csv.NewReader(reader)
+	if opts.Comma != 0 {
+		csvReader.Comma = opts.Comma
+	}
+	csvReader.Comment = opts.Comment
+
+	t := NewWriter(writer)
+	if err := t.readCSV(csvReader, opts.HasHeader, opts.MaxRows); err != nil {
+		return &Table{}, err
+	}
+	if opts.SniffTypes {
+		t.SetColumnAlignment(sniffAlignment(t.rows))
+	}
+	return t, nil
+}
+
+// readCSV appends the records of csvReader, at most maxRows of them if
+// maxRows is greater than zero.
+func (t *Table) readCSV(csvReader *csv.Reader, hasHeader bool, maxRows int) error {
 	if hasHeader {
 		// Read the first row
 		headers, err := csvReader.Read()
 		if err != nil {
-			return &Table{}, err
+			return err
 		}
 		t.SetHeader(headers)
 	}
-	for {
+	for n := 0; maxRows <= 0 || n < maxRows; n++ {
 		record, err := csvReader.Read()
 		if err == io.EOF {
 			break
 		} else if err != nil {
-			return &Table{}, err
+			return err
 		}
 		t.Append(record)
 	}
-	return t, nil
+	return nil
+}
+
+// sniffAlignment returns ALIGN_RIGHT for the columns of rows holding only
+// numbers and ALIGN_LEFT for the others. Empty values are ignored.
+func sniffAlignment(rows [][]string) []int {
+	var align []int
+	for _, row := range rows {
+		for i, v := range row {
+			for len(align) <= i {
+				align = append(align, ALIGN_RIGHT)
+			}
+			v = strings.TrimSpace(v)
+			if v != "" && !decimal.MatchString(v) && !percent.MatchString(v) {
+				align[i] = ALIGN_LEFT
+			}
+		}
+	}
+	return align
 }
@@ -0,0 +1,185 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "fmt"
+
+// UpdateRow Replace the cells of row i
+// The row is processed as if appended, and the row transform receives i.
+// Column widths are worked out again from all rows.
+func (t *Table) UpdateRow(i int, row []string) error {
+	defer t.lock()()
+	if i < 0 || i >= len(t.rows) {
+		return fmt.Errorf("row %d out of range", i)
+	}
+	return t.setRow(i, t.computeRow(t.transformRow(i, row)))
+}
+
+// UpdateCell Replace cell col of row i
+// Computed columns are computed again; the row transform is not applied.
+func (t *Table) UpdateCell(i, col int, value string) error {
+	defer t.lock()()
+	if i < 0 || i >= len(t.rows) || col < 0 {
+		return fmt.Errorf("cell %d of row %d out of range", col, i)
+	}
+	row := append([]string(nil), t.rows[i]...)
+	if len(t.computed) > 0 {
+		row = row[:len(row)-len(t.computed)]
+		if col >= len(row) {
+			return fmt.Errorf("cell %d of row %d is computed", col, i)
+		}
+	}
+	if col >= len(row) {
+		row = append(row, make([]string, col+1-len(row))...)
+	}
+	row[col] = value
+	return t.setRow(i, t.computeRow(row))
+}
+
+// RemoveRow Remove row i
+// Settings of the rows below it, such as colors and separators, move up
+// with them.
+func (t *Table) RemoveRow(i int) error {
+	defer t.lock()()
+	if i < 0 || i >= len(t.rows) {
+		return fmt.Errorf("row %d out of range", i)
+	}
+	t.rows = append(t.rows[:i], t.rows[i+1:]...)
+	t.lines = append(t.lines[:i], t.lines[i+1:]...)
+	delete(t.rowColors, i)
+	delete(t.cellStyles, i)
+	delete(t.treeDepths, i)
+	delete(t.separators, i)
+	for cell := range t.nestedCells {
+		if cell[0] == i {
+			delete(t.nestedCells, cell)
+		}
+	}
+	// Dividers above the row stay above the row taking its place.
+	if above := t.dividers[i]; above != nil {
+		t.dividers[i+1] = append(above, t.dividers[i+1]...)
+		delete(t.dividers, i)
+	}
+	t.shiftRows(i+1, -1)
+	t.reparse()
+	return nil
+}
+
+// InsertRow Insert row before row i, or append it when i is the number of
+// rows
+// Dividers above row i stay above the inserted row.
+func (t *Table) InsertRow(i int, row []string) error {
+	defer t.lock()()
+	if i < 0 || i > len(t.rows) {
+		return fmt.Errorf("row %d out of range", i)
+	}
+	row = t.computeRow(t.transformRow(i, row))
+	if !t.checkColumns(i, len(row)) {
+		return t.err
+	}
+	above := t.dividers[i]
+	delete(t.dividers, i)
+	t.shiftRows(i, 1)
+	if above != nil {
+		t.dividers[i] = above
+	}
+	t.rows = append(t.rows, nil)
+	copy(t.rows[i+1:], t.rows[i:])
+	t.rows[i] = nil
+	t.lines = append(t.lines, nil)
+	copy(t.lines[i+1:], t.lines[i:])
+	t.lines[i] = nil
+	return t.setRow(i, row)
+}
+
+// setRow stores row as the cells of row i and sizes the columns again.
+func (t *Table) setRow(i int, row []string) error {
+	if !t.checkColumns(i, len(row)) {
+		return t.err
+	}
+	raw := make([]string, len(row))
+	for y, v := range row {
+		raw[y] = t.cleanCell(y, v)
+	}
+	t.rows[i] = raw
+	t.lines[i] = make([][]string, len(raw))
+	t.reparse()
+	return nil
+}
+
+// shiftRows moves the settings of rows from row from on by delta rows.
+func (t *Table) shiftRows(from, delta int) {
+	move := func(i int) (int, bool) {
+		return i + delta, i >= from
+	}
+	rowColors := make(map[int][]Colors)
+	for i, v := range t.rowColors {
+		if j, ok := move(i); ok {
+			i = j
+		}
+		rowColors[i] = v
+	}
+	cellStyles := make(map[int][]Cell)
+	for i, v := range t.cellStyles {
+		if j, ok := move(i); ok {
+			i = j
+		}
+		cellStyles[i] = v
+	}
+	treeDepths := make(map[int]int)
+	for i, v := range t.treeDepths {
+		if j, ok := move(i); ok {
+			i = j
+		}
+		treeDepths[i] = v
+	}
+	separators := make(map[int]bool)
+	for i, v := range t.separators {
+		if j, ok := move(i); ok {
+			i = j
+		}
+		separators[i] = v
+	}
+	dividers := make(map[int][]rowDivider)
+	for i, v := range t.dividers {
+		if j, ok := move(i); ok {
+			i = j
+		}
+		dividers[i] = v
+	}
+	nestedCells := make(map[[2]int]bool)
+	for cell, v := range t.nestedCells {
+		if j, ok := move(cell[0]); ok {
+			cell[0] = j
+		}
+		nestedCells[cell] = v
+	}
+	t.rowColors, t.cellStyles, t.treeDepths = rowColors, cellStyles, treeDepths
+	t.separators, t.dividers, t.nestedCells = separators, dividers, nestedCells
+}
+
+// reparse works out the column widths, the row heights and the lines of
+// every cell again from the stored cells.
+func (t *Table) reparse() {
+	t.cs = make(map[int]int)
+	for col, width := range t.minWidths {
+		t.cs[col] = width
+	}
+	t.rs = make(map[int]int)
+	for y, v := range t.rawHeaders {
+		t.headers[y] = t.parseDimension(v, y, headerRowIdx)
+	}
+	for y, v := range t.rawFooters {
+		t.footers[y] = t.parseDimension(v, y, footerRowIdx)
+	}
+	for i, raw := range t.rows {
+		for y, v := range raw {
+			t.lines[i][y] = t.parseDimension(v, y, i)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// Bytes Format a byte count with binary units, e.g. 1536 as "1.5 KiB"
+func Bytes(n int64) string {
+	return formatBytes(float64(n), 1)
+}
+
+// BytesFormatter Return a column formatter printing byte counts
+// Values of any integer or float kind are printed like Bytes with precision
+// decimals, other values with fmt.Sprint. Use it with SetColumnFormatter.
+func BytesFormatter(precision int) func(interface{}) string {
+	return func(v interface{}) string {
+		if v == nil {
+			return ""
+		}
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return formatBytes(float64(rv.Int()), precision)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return formatBytes(float64(rv.Uint()), precision)
+		case reflect.Float32, reflect.Float64:
+			return formatBytes(rv.Float(), precision)
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+func formatBytes(n float64, precision int) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	i := 0
+	for ; n >= 1024 && i < len(byteUnits)-1; i++ {
+		n /= 1024
+	}
+	if i == 0 {
+		return sign + strconv.FormatFloat(n, 'f', 0, 64) + " B"
+	}
+	return sign + strconv.FormatFloat(n, 'f', precision, 64) + " " + byteUnits[i]
+}
+
+// relativeLayout is the layout of times too far from now to be printed
+// relative to it.
+const relativeLayout = "2006-01-02"
+
+// RelativeTime Format a time relative to now, e.g. "3 hours ago"
+// Times 30 days or more away from now are printed as dates.
+func RelativeTime(tm time.Time) string {
+	return humanTime(tm, time.Now(), relativeLayout)
+}
+
+// RelativeTimeFormatter Return a column formatter printing relative times
+// time.Time values are printed like RelativeTime, falling back to layout,
+// other values with fmt.Sprint. Use it with SetColumnFormatter.
+func RelativeTimeFormatter(layout string) func(interface{}) string {
+	return func(v interface{}) string {
+		switch tm := v.(type) {
+		case time.Time:
+			return humanTime(tm, time.Now(), layout)
+		case nil:
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// humanTime describes tm relative to now in words, or formats it with layout
+// when it is 30 days or more away.
+func humanTime(tm, now time.Time, layout string) string {
+	d := now.Sub(tm)
+	ago := d >= 0
+	if !ago {
+		d = -d
+	}
+	var n int64
+	var unit string
+	switch day := 24 * time.Hour; {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "minute"
+	case d < day:
+		n, unit = int64(d/time.Hour), "hour"
+	case d < 30*day:
+		n, unit = int64(d/day), "day"
+	default:
+		return tm.Format(layout)
+	}
+	if n > 1 {
+		unit += "s"
+	}
+	if ago {
+		return fmt.Sprintf("%d %s ago", n, unit)
+	}
+	return fmt.Sprintf("in %d %s", n, unit)
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline Draw values as a line of block characters, e.g. "▁▃▅█"
+// The bars scale from the lowest to the highest value. With a positive
+// width, consecutive values are averaged so that the line is no wider than
+// width, as fits a column of SetColMinWidth or SetColFixedWidths. NaN and
+// infinite values are drawn as spaces.
+func Sparkline(values []float64, width int) string {
+	if width > 0 && len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			from, to := i*len(values)/width, (i+1)*len(values)/width
+			sum, n := 0.0, 0
+			for _, v := range values[from:to] {
+				if finite(v) {
+					sum += v
+					n++
+				}
+			}
+			buckets[i] = math.NaN()
+			if n > 0 {
+				buckets[i] = sum / float64(n)
+			}
+		}
+		values = buckets
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if finite(v) {
+			min, max = math.Min(min, v), math.Max(max, v)
+		}
+	}
+	line := make([]rune, len(values))
+	for i, v := range values {
+		switch {
+		case !finite(v):
+			line[i] = ' '
+		case max == min:
+			line[i] = sparks[0]
+		default:
+			line[i] = sparks[int((v-min)/(max-min)*float64(len(sparks)-1)+0.5)]
+		}
+	}
+	return string(line)
+}
+
+// finite tells whether v is neither NaN nor infinite.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
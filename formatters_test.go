@@ -0,0 +1,66 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package tablewriter
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{5 << 20, "5.0 MiB"},
+		{-2048, "-2.0 KiB"},
+		{3 << 40, "3.0 TiB"},
+	}
+	for _, tt := range tests {
+		checkEqual(t, Bytes(tt.n), tt.want)
+	}
+
+	format := BytesFormatter(2)
+	checkEqual(t, format(uint64(1)<<30+1<<29), "1.50 GiB")
+	checkEqual(t, format(uint16(2048)), "2.00 KiB")
+	checkEqual(t, format(int8(-100)), "-100 B")
+	checkEqual(t, format(float32(1536)), "1.50 KiB")
+	checkEqual(t, format(nil), "")
+	checkEqual(t, format("n/a"), "n/a")
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2020, 5, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		tm   time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.Add(49 * time.Hour), "in 2 days"},
+		{now.AddDate(0, -2, 0), "17 Mar 20"},
+	}
+	for _, tt := range tests {
+		checkEqual(t, humanTime(tt.tm, now, "02 Jan 06"), tt.want)
+	}
+
+	format := RelativeTimeFormatter(relativeLayout)
+	checkEqual(t, format(time.Now().Add(-2*time.Hour)), "2 hours ago")
+	checkEqual(t, format(nil), "")
+}
+
+func TestSparkline(t *testing.T) {
+	checkEqual(t, Sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 0), "▁▂▃▄▅▆▇█")
+	checkEqual(t, Sparkline([]float64{1, math.NaN(), 1}, 0), "▁ ▁")
+	checkEqual(t, Sparkline([]float64{1, 2, math.Inf(1)}, 0), "▁█ ")
+	checkEqual(t, Sparkline([]float64{math.Inf(-1), 1, 2, 3}, 2), "▁█")
+	checkEqual(t, Sparkline([]float64{0, 0, 7, 7, 14, 14}, 3), "▁▅█")
+	checkEqual(t, Sparkline(nil, 5), "")
+}
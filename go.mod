@@ -2,4 +2,7 @@ module github.com/olekukonko/tablewriter
 
 go 1.12
 
-require github.com/mattn/go-runewidth v0.0.10
+require (
+	github.com/mattn/go-runewidth v0.0.10
+	github.com/rivo/uniseg v0.1.0
+)
@@ -0,0 +1,203 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rowGroup is a group of rows sharing the value of the group by column.
+type rowGroup struct {
+	key      string
+	rows     []int
+	subtotal [][]string
+}
+
+// SetGroupBy Group rows by the value of a column
+// Rows with the same value in column are printed together, in the order
+// their values first appear once sorted, below a line holding the value, which
+// is left out of the rows themselves. Auto merge is not applied to grouped
+// rows. A negative column disables grouping.
+func (t *Table) SetGroupBy(column int) {
+	defer t.lock()()
+	t.groupBy = column
+	t.resetGroups()
+}
+
+// SetGroupSubtotals Sum columns of grouped rows
+// A subtotal row with the sums of columns is printed after each group and,
+// if total is set, the footer holds the grand totals. A footer set with
+// SetFooter is kept and takes the place of the grand totals.
+// Cells of columns that are not numbers are left out of the sums.
+func (t *Table) SetGroupSubtotals(total bool, columns ...int) {
+	defer t.lock()()
+	t.groupTotal = total
+	t.subtotalCols = columns
+	t.resetGroups()
+}
+
+// resetGroups drops the grand totals footer and the widths of the subtotal
+// rows of the last render, which the next render works out again.
+func (t *Table) resetGroups() {
+	if t.totalFooter {
+		t.footers, t.rawFooters, t.totalFooter = nil, nil, false
+	}
+	if t.groups != nil {
+		t.groups = nil
+		t.reparse()
+	}
+}
+
+// subtotalRowIdx returns the key subtotal row of group g is printed with.
+// Its height is never stored in t.rs, where negative keys mark the header
+// and footer.
+func subtotalRowIdx(g int) int {
+	return footerRowIdx - 1 - g
+}
+
+// prepareGroups groups the rows and sizes the subtotal and total rows.
+func (t *Table) prepareGroups() {
+	t.groups = nil
+	if t.groupBy < 0 {
+		return
+	}
+	index := make(map[string]int)
+	for _, i := range t.pagedRows() {
+		row := t.rows[i]
+		key := ""
+		if t.groupBy < len(row) {
+			key = row[t.groupBy]
+		}
+		g, ok := index[key]
+		if !ok {
+			g = len(t.groups)
+			index[key] = g
+			t.groups = append(t.groups, rowGroup{key: key})
+		}
+		t.groups[g].rows = append(t.groups[g].rows, i)
+	}
+	if len(t.subtotalCols) == 0 {
+		return
+	}
+
+	for g := range t.groups {
+		cells := t.sumRows(t.groups[g].rows, "Subtotal")
+		for y, v := range cells {
+			lines, width := t.measureCell(v, y, subtotalRowIdx(g))
+			if width > t.cs[y] {
+				t.cs[y] = width
+			}
+			t.groups[g].subtotal = append(t.groups[g].subtotal, lines)
+		}
+	}
+	if t.groupTotal && (len(t.rawFooters) == 0 || t.totalFooter) {
+		t.footers = nil
+		t.rawFooters = t.sumRows(t.visibleRows(), "Total")
+		for y, v := range t.rawFooters {
+			t.footers = append(t.footers, t.parseDimension(v, y, footerRowIdx))
+		}
+		t.totalFooter = true
+	}
+}
+
+// sumRows returns a row with label in the group by column and the sums of
+// the subtotal columns over rows.
+func (t *Table) sumRows(rows []int, label string) []string {
+	cells := make([]string, len(t.cs))
+	if t.groupBy < len(cells) {
+		cells[t.groupBy] = label
+	}
+	for _, y := range t.subtotalCols {
+		if y < 0 || y >= len(cells) {
+			continue
+		}
+		var sum float64
+		decimals, grouped := 0, false
+		for _, i := range rows {
+			if y >= len(t.rows[i]) {
+				continue
+			}
+			v := strings.TrimSpace(t.rows[i][y])
+			if !decimal.MatchString(v) {
+				continue
+			}
+			if strings.Contains(v, ",") {
+				grouped = true
+				v = strings.Replace(v, ",", "", -1)
+			}
+			if dot := strings.IndexByte(v, '.'); dot >= 0 && len(v)-dot-1 > decimals {
+				decimals = len(v) - dot - 1
+			}
+			n, _ := strconv.ParseFloat(v, 64)
+			sum += n
+		}
+		cells[y] = strconv.FormatFloat(sum, 'f', decimals, 64)
+		if grouped {
+			cells[y] = GroupDigits(cells[y], ",", ".")
+		}
+	}
+	return cells
+}
+
+// groupedLine returns the lines of row i with the group by column left
+// blank, as its value is printed above the group.
+func (t *Table) groupedLine(i int) [][]string {
+	line := t.lines[i]
+	if t.groupBy >= len(line) {
+		return line
+	}
+	blanked := append([][]string(nil), line...)
+	blanked[t.groupBy] = []string{""}
+	return blanked
+}
+
+// printGroupedRows prints the rows group by group.
+func (t *Table) printGroupedRows() {
+	total := 0
+	for _, group := range t.groups {
+		total += len(group.rows)
+	}
+	limit := total
+	if t.maxRows > 0 && limit > t.maxRows {
+		limit = t.maxRows
+	}
+	printed := 0
+	for g, group := range t.groups {
+		if printed >= limit {
+			break
+		}
+		if g > 0 {
+			t.printBoundaryLine(t.columnSeparator, never, false)
+		}
+		t.printSpanningRow(group.key, ALIGN_LEFT, "", SPACE)
+		t.printBoundaryLine(never, t.columnSeparator, false)
+		for k, i := range group.rows {
+			if printed >= limit {
+				break
+			}
+			if k > 0 && (t.rowLine || t.separators[group.rows[k-1]]) {
+				t.printRowLine(false)
+			}
+			t.rowPos = printed
+			t.printRow(t.groupedLine(i), i)
+			printed++
+		}
+		if group.subtotal != nil {
+			t.printLine(false, false)
+			t.printRow(group.subtotal, subtotalRowIdx(g))
+		}
+	}
+	if more := total - printed; more > 0 {
+		t.printBoundaryLine(t.columnSeparator, never, false)
+		t.printMoreRows(more)
+	}
+	if t.rowLine {
+		t.printRowLine(true)
+	}
+}
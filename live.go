@@ -0,0 +1,66 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetLive Redraw the table in place on every Render
+// Each Render after the first moves the cursor back up over the lines
+// printed last and erases them, so that a terminal shows a continuously
+// updated table. Nothing else should be written in between.
+func (t *Table) SetLive(live bool) {
+	t.live = live
+	t.liveLines = 0
+}
+
+// renderLive renders the table over the one rendered last.
+func (t *Table) renderLive() {
+	// Colors and width are those of the terminal, not of the buffer.
+	t.term = t.out
+	buf := t.renderBuffer()
+	t.term = nil
+	defer bufPool.Put(buf)
+	if t.liveLines > 0 {
+		fmt.Fprintf(t.out, "%s[%dA%s[J", ESC, t.liveLines, ESC)
+	}
+	t.liveLines = strings.Count(buf.String(), "\n")
+	buf.WriteTo(t.out)
+}
+
+// Watch Redraw the table in place every interval until ctx is done
+// Each time the rows are cleared and the columns sized by the header and
+// footer alone, refresh is called to append the current rows and the table
+// is rendered live. Watch returns the context error.
+func (t *Table) Watch(ctx context.Context, interval time.Duration, refresh func(*Table)) error {
+	t.SetLive(true)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		t.clearLive()
+		refresh(t)
+		t.Render()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// clearLive clears the rows and the widths and heights they set, so that
+// columns narrow again when the rows do.
+func (t *Table) clearLive() {
+	defer t.lock()()
+	t.clearRows()
+	t.reparse()
+}
@@ -0,0 +1,75 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "sync"
+
+// SetParallelPrepare Wrap and measure the cells of rows appended with
+// AppendBulk on workers goroutines
+// Rows are still transformed, computed and sanitized one after the other
+// and are stored in order, so that the output is the same as when
+// appended one by one. Default is 0, no workers.
+func (t *Table) SetParallelPrepare(workers int) {
+	t.workers = workers
+}
+
+// appendParallel appends rows, measuring their cells on t.workers
+// goroutines.
+func (t *Table) appendParallel(rows [][]string) {
+	type prepared struct {
+		raw    []string
+		lines  [][]string
+		widths []int
+	}
+	base := len(t.lines)
+	jobs := make([]prepared, 0, len(rows))
+	for _, row := range rows {
+		row = t.computeRow(t.transformRow(base+len(jobs), row))
+		if !t.checkColumns(base+len(jobs), len(row)) {
+			continue
+		}
+		raw := make([]string, len(row))
+		for i, v := range row {
+			raw[i] = t.cleanCell(i, v)
+		}
+		jobs = append(jobs, prepared{raw: raw})
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < t.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range next {
+				job := &jobs[k]
+				job.lines = make([][]string, len(job.raw))
+				job.widths = make([]int, len(job.raw))
+				for i, v := range job.raw {
+					job.lines[i], job.widths[i] = t.measureCell(v, i, base+k)
+				}
+			}
+		}()
+	}
+	for k := range jobs {
+		next <- k
+	}
+	close(next)
+	wg.Wait()
+
+	if len(t.headers) > t.colSize {
+		t.colSize = len(t.headers)
+	}
+	for k, job := range jobs {
+		for i, lines := range job.lines {
+			t.recordDimension(i, base+k, job.widths[i], len(lines))
+		}
+		t.lines = append(t.lines, job.lines)
+		t.rows = append(t.rows, job.raw)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "strings"
+
+// Snapshot is the content of a table laid out as Render prints it, for
+// tools drawing tables by other means than text.
+type Snapshot struct {
+	// Widths holds the width of every printed column.
+	Widths []int
+	// Header holds the lines of every header cell.
+	Header [][]string
+	// Rows holds the printed rows in the order they are printed.
+	Rows []SnapshotRow
+	// Footer holds the lines of every footer cell.
+	Footer [][]string
+}
+
+// SnapshotRow is a printed row of a Snapshot.
+type SnapshotRow struct {
+	// Index is the index of the row as appended.
+	Index int
+	// Cells holds the lines of every cell.
+	Cells [][]string
+	// Merged tells for every cell whether auto merge joins it to the cell
+	// above, leaving it blank.
+	Merged []bool
+}
+
+// Snapshot returns the table laid out as Render would print it, after
+// wrapping, sorting, filtering and fitting the columns. Subtotal rows and
+// rows beyond SetMaxRows are left out.
+func (t *Table) Snapshot() Snapshot {
+	defer t.lock()()
+	l, restore := t.laidOut()
+	defer restore()
+
+	var s Snapshot
+	for y := 0; y < len(l.cs); y++ {
+		s.Widths = append(s.Widths, l.cs[y])
+	}
+	s.Header = l.formatLines(SectionHeader, l.headers)
+	s.Footer = l.formatLines(SectionFooter, l.footers)
+
+	var previous []string
+	for _, i := range l.printedRows() {
+		row := SnapshotRow{Index: i, Cells: copyLines(l.treeColumns(l.lines[i], i))}
+		runs := l.mergeRuns(previous, row.Cells)
+		for y, lines := range row.Cells {
+			full := strings.TrimRight(strings.Join(lines, " "), " ")
+			merged := l.autoMergeCells && len(l.groups) == 0 && y < len(previous) &&
+				full != "" && previous[y] != "" && l.isMergeColumn(y) && l.mergeEqual(y, previous[y], full)
+			row.Merged = append(row.Merged, merged)
+		}
+		previous = runs
+		s.Rows = append(s.Rows, row)
+	}
+	return s
+}
+
+// laidOut returns the table as it is printed, with the shown columns only,
+// transposed or mirrored if so set, and its columns fitted, along with a
+// function restoring the table once done with it.
+func (t *Table) laidOut() (*Table, func()) {
+	if cols := t.shownColumns(); cols != nil {
+		return t.project(cols).laidOut()
+	}
+	if t.transpose {
+		return t.transposed().laidOut()
+	}
+	if t.rtl {
+		return t.mirrored().laidOut()
+	}
+	t.applyConditionalWidths()
+	t.applyFixedWidths()
+	t.prepareTree()
+	t.prepareGroups()
+	t.detectNumericColumns()
+	return t, t.fitted()
+}
+
+// printedRows returns the indexes of the data rows in the order they are
+// printed.
+func (t *Table) printedRows() []int {
+	if len(t.groups) == 0 {
+		order, _ := t.rowOrder()
+		return order
+	}
+	var order []int
+	for _, group := range t.groups {
+		order = append(order, group.rows...)
+	}
+	if t.maxRows > 0 && len(order) > t.maxRows {
+		order = order[:t.maxRows]
+	}
+	return order
+}
+
+// formatLines returns a copy of the lines of the header or footer cells of
+// section as printed.
+func (t *Table) formatLines(section int, cells [][]string) [][]string {
+	out := copyLines(cells)
+	if t.autoFmt {
+		for y, lines := range out {
+			for i, line := range lines {
+				lines[i] = t.autoTitle(section, y, line)
+			}
+		}
+	}
+	return out
+}
+
+// Layout describes where Render places the columns and rows of a table, to
+// draw content aligned with it.
+type Layout struct {
+	// Widths holds the width of the content of every printed column.
+	Widths []int
+	// Offsets holds the position in a line of the content of every
+	// printed column.
+	Offsets []int
+	// Width is the width of the printed lines.
+	Width int
+	// Hidden holds the columns, as appended, that are not printed.
+	Hidden []int
+	// HeaderHeight is the number of lines of the header.
+	HeaderHeight int
+	// Heights holds the number of lines of every printed row, in the order
+	// they are printed.
+	Heights []int
+	// FooterHeight is the number of lines of the footer.
+	FooterHeight int
+}
+
+// LayoutInfo returns the layout of the table as Render prints it.
+func (t *Table) LayoutInfo() Layout {
+	defer t.lock()()
+	var layout Layout
+	if cols := t.shownColumns(); cols != nil {
+		shown := make(map[int]bool)
+		for _, c := range cols {
+			shown[c] = true
+		}
+		for y := 0; y < len(t.cs); y++ {
+			if !shown[y] {
+				layout.Hidden = append(layout.Hidden, y)
+			}
+		}
+	}
+
+	l, restore := t.laidOut()
+	defer restore()
+	pos, gap := 2, 3
+	if l.noWhiteSpace {
+		pos, gap = 0, DisplayWidth(l.tablePadding)
+	}
+	for y := 0; y < len(l.cs); y++ {
+		layout.Widths = append(layout.Widths, l.cs[y])
+		layout.Offsets = append(layout.Offsets, pos)
+		pos += l.cs[y] + gap
+	}
+	layout.Width = pos - 1
+	if l.noWhiteSpace {
+		layout.Width = pos
+	}
+	if len(l.headers) > 0 {
+		layout.HeaderHeight = l.rs[headerRowIdx]
+	}
+	for _, i := range l.printedRows() {
+		layout.Heights = append(layout.Heights, l.rs[i])
+	}
+	if len(l.footers) > 0 {
+		layout.FooterHeight = l.rs[footerRowIdx]
+	}
+	return layout
+}
@@ -0,0 +1,50 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "database/sql"
+
+// AppendRows Append the result of a query
+// The column names become the header and every remaining row of rows is
+// appended. Values are formatted as by AppendAny, with NULL values printed
+// as NULL in columns without a formatter. rows is left for the caller to
+// close.
+func (t *Table) AppendRows(rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	t.SetHeader(columns)
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			switch b := v.(type) {
+			case nil:
+				row[i] = "NULL"
+				if t.colFormatters[i] != nil {
+					row[i] = t.formatValue(i, v)
+				}
+			case []byte:
+				row[i] = t.formatValue(i, string(b))
+			default:
+				row[i] = t.formatValue(i, v)
+			}
+		}
+		t.Append(row)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,112 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package tablewriter
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeDriver serves the same result set for every query.
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct{}
+
+type fakeRows struct {
+	i int
+}
+
+var fakeResult = [][]driver.Value{
+	{int64(1), "Gopher", []byte("blue")},
+	{int64(2), "Ferris", nil},
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error)         { return fakeConn{}, nil }
+func (fakeConn) Prepare(string) (driver.Stmt, error)        { return fakeStmt{}, nil }
+func (fakeConn) Close() error                               { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                  { return nil, driver.ErrSkip }
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return 0 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return &fakeRows{}, nil }
+func (*fakeRows) Columns() []string                         { return []string{"id", "name", "color"} }
+func (*fakeRows) Close() error                              { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(fakeResult) {
+		return io.EOF
+	}
+	copy(dest, fakeResult[r.i])
+	r.i++
+	return nil
+}
+
+func init() {
+	sql.Register("tablewriter-fake", fakeDriver{})
+}
+
+func TestAppendRows(t *testing.T) {
+	db, err := sql.Open("tablewriter-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT id, name, color FROM mascots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	if err := table.AppendRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+----+--------+-------+
+| ID |  NAME  | COLOR |
++----+--------+-------+
+|  1 | Gopher | blue  |
+|  2 | Ferris | NULL  |
++----+--------+-------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// Values go through the column formatters.
+	rows, err = db.Query("SELECT id, name, color FROM mascots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetColumnFormatter(0, func(v interface{}) string { return fmt.Sprintf("#%v", v) })
+	table.SetColumnFormatter(2, func(v interface{}) string {
+		if v == nil {
+			return "-"
+		}
+		return strings.ToUpper(v.(string))
+	})
+	if err := table.AppendRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+	want = `+----+--------+-------+
+| ID |  NAME  | COLOR |
++----+--------+-------+
+| #1 | Gopher | BLUE  |
+| #2 | Ferris | -     |
++----+--------+-------+
+`
+	checkEqual(t, buf.String(), want)
+}
@@ -10,12 +10,17 @@ package tablewriter
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -23,11 +28,12 @@ const (
 )
 
 const (
-	CENTER  = "+"
-	ROW     = "-"
-	COLUMN  = "|"
-	SPACE   = " "
-	NEWLINE = "\n"
+	CENTER   = "+"
+	ROW      = "-"
+	COLUMN   = "|"
+	SPACE    = " "
+	NEWLINE  = "\n"
+	ELLIPSIS = "..."
 )
 
 const (
@@ -37,6 +43,17 @@ const (
 	ALIGN_LEFT
 )
 
+const (
+	CAPTION_BOTTOM = iota
+	CAPTION_TOP
+)
+
+const (
+	TRUNCATE_END = iota
+	TRUNCATE_START
+	TRUNCATE_MIDDLE
+)
+
 var (
 	decimal = regexp.MustCompile(`^-?(?:\d{1,3}(?:,\d{3})*|\d+)(?:\.\d+)?$`)
 	percent = regexp.MustCompile(`^-?\d+\.?\d*$%$`)
@@ -76,6 +93,8 @@ type Table struct {
 	rs                      map[int]int
 	headers                 [][]string
 	footers                 [][]string
+	rawHeaders              []string
+	rawFooters              []string
 	caption                 bool
 	captionText             string
 	autoFmt                 bool
@@ -95,6 +114,48 @@ type Table struct {
 	rowLine                 bool
 	autoMergeCells          bool
 	columnsToAutoMergeCells map[int]bool
+	noMergeColumns          map[int]bool
+	keepSpaceColumns        map[int]bool
+	strict                  bool
+	err                     error
+	mergeCompare            func(a, b string) bool
+	colMergeCompare         map[int]func(a, b string) bool
+	mu                      *sync.Mutex
+	strictMapKeys           bool
+	computed                []computedColumn
+	baseCols                int
+	colFormatters           map[int]func(interface{}) string
+	numberFormats           map[int]numberFormat
+	colIcons                map[int]map[string]string
+	timeLayout              string
+	colTimeLayouts          map[int]string
+	now                     func() time.Time
+	numAlignHeader          bool
+	numAlignRows            bool
+	numAlignFooter          bool
+	numericCols             map[int]bool
+	groupBy                 int
+	subtotalCols            []int
+	groupTotal              bool
+	totalFooter             bool
+	groups                  []rowGroup
+	sortKeys                []SortKey
+	rowPerm                 []int
+	rowFilter               func(cells []string, idx int) bool
+	pageStart               int
+	pageSize                int
+	transpose               bool
+	visibleCols             []string
+	hiddenCols              map[int]bool
+	treeCol                 int
+	treeDepths              map[int]int
+	treePrefixes            map[int][2]string
+	stripes                 [2]string
+	cellCallback            func(CellContext)
+	cellHook                func(ctx CellContext, cell string) string
+	rowTransform            func(rowIdx int, cells []string) []string
+	rowPos                  int
+	nestedCells             map[[2]int]bool
 	noWhiteSpace            bool
 	tablePadding            string
 	hdrLine                 bool
@@ -104,67 +165,336 @@ type Table struct {
 	columnsParams           []string
 	footerParams            []string
 	columnsAlign            []int
+	condMinWidths           map[int][]conditionalWidth
+	sanitizer               func(string) string
+	colorMode               ColorMode
+	maxWidth                int
+	autoTermWidth           bool
+	rowColors               map[int][]Colors
+	minWidths               map[int]int
+	fixedWidths             map[int]int
+	shrinkPriority          map[int]int
+	maxRows                 int
+	hMaxLines               int
+	fMaxLines               int
+	maxLines                int
+	lineEllipsis            string
+	splitWide               bool
+	splitKey                int
+	captionPos              int
+	captionAlign            int
+	title                   string
+	titleAlign              int
+	titleParams             string
+	footnotes               []string
+	headerGroups            []HeaderGroup
+	cellStyles              map[int][]Cell
+	sectionSyms             map[int][]string
+	separators              map[int]bool
+	dividers                map[int][]rowDivider
+	verticalHeader          bool
+	rtl                     bool
+	tabWidth                int
+	truncatePos             int
+	ellipsis                string
+	colEllipsis             map[int]string
+	heatMaps                map[int][]Colors
+	borderParams            string
+	noSeparators            map[int]bool
+	workers                 int
+	heatRanges              map[int][2]float64
+	live                    bool
+	colorCached, colorOn    bool
+	term                    io.Writer
+	liveLines               int
+}
+
+// HeaderGroup is a header cell spanning Span columns, printed above the
+// table header.
+type HeaderGroup struct {
+	Title string
+	Span  int
+}
+
+// conditionalWidth raises the width of a column to width when a cell in that
+// column matches matcher.
+type conditionalWidth struct {
+	matcher *regexp.Regexp
+	width   int
 }
 
 // NewWriter Start New Table
 // Take io.Writer Directly
 func NewWriter(writer io.Writer) *Table {
 	t := &Table{
-		out:           writer,
-		rows:          [][]string{},
-		lines:         [][][]string{},
-		cs:            make(map[int]int),
-		rs:            make(map[int]int),
-		headers:       [][]string{},
-		footers:       [][]string{},
-		caption:       false,
-		captionText:   "Table caption.",
-		autoFmt:       true,
-		autoWrap:      true,
-		reflowText:    true,
-		mW:            MAX_ROW_WIDTH,
-		syms:          simpleSyms(CENTER, ROW, COLUMN),
-		pCenter:       CENTER,
-		pRow:          ROW,
-		pColumn:       COLUMN,
-		tColumn:       -1,
-		tRow:          -1,
-		hAlign:        ALIGN_DEFAULT,
-		fAlign:        ALIGN_DEFAULT,
-		align:         ALIGN_DEFAULT,
-		newLine:       NEWLINE,
-		rowLine:       false,
-		hdrLine:       true,
-		borders:       Border{Left: true, Right: true, Bottom: true, Top: true},
-		colSize:       -1,
-		headerParams:  []string{},
-		columnsParams: []string{},
-		footerParams:  []string{},
-		columnsAlign:  []int{}}
+		out:             writer,
+		rows:            [][]string{},
+		lines:           [][][]string{},
+		cs:              make(map[int]int),
+		rs:              make(map[int]int),
+		headers:         [][]string{},
+		footers:         [][]string{},
+		caption:         false,
+		captionText:     "Table caption.",
+		autoFmt:         true,
+		autoWrap:        true,
+		reflowText:      true,
+		mW:              MAX_ROW_WIDTH,
+		syms:            simpleSyms(CENTER, ROW, COLUMN),
+		pCenter:         CENTER,
+		pRow:            ROW,
+		pColumn:         COLUMN,
+		tColumn:         -1,
+		tRow:            -1,
+		hAlign:          ALIGN_DEFAULT,
+		fAlign:          ALIGN_DEFAULT,
+		align:           ALIGN_DEFAULT,
+		newLine:         NEWLINE,
+		rowLine:         false,
+		hdrLine:         true,
+		borders:         Border{Left: true, Right: true, Bottom: true, Top: true},
+		colSize:         -1,
+		headerParams:    []string{},
+		columnsParams:   []string{},
+		footerParams:    []string{},
+		columnsAlign:    []int{},
+		condMinWidths:   make(map[int][]conditionalWidth),
+		sanitizer:       StripControl,
+		rowColors:       make(map[int][]Colors),
+		minWidths:       make(map[int]int),
+		fixedWidths:     make(map[int]int),
+		shrinkPriority:  make(map[int]int),
+		lineEllipsis:    ELLIPSIS,
+		cellStyles:      make(map[int][]Cell),
+		colMergeCompare: make(map[int]func(a, b string) bool),
+		colFormatters:   make(map[int]func(interface{}) string),
+		numberFormats:   make(map[int]numberFormat),
+		colIcons:        make(map[int]map[string]string),
+		colTimeLayouts:  make(map[int]string),
+		now:             time.Now,
+		groupBy:         -1,
+		hiddenCols:      make(map[int]bool),
+		treeCol:         -1,
+		treeDepths:      make(map[int]int),
+		nestedCells:     make(map[[2]int]bool),
+		sectionSyms:     make(map[int][]string),
+		separators:      make(map[int]bool),
+		dividers:        make(map[int][]rowDivider),
+		tabWidth:        8,
+		ellipsis:        ELLIPSIS,
+		colEllipsis:     make(map[int]string),
+		heatMaps:        make(map[int][]Colors),
+		noSeparators:    make(map[int]bool)}
 	return t
 }
 
 // Render table output
 func (t *Table) Render() {
-	if t.borders.Top {
-		t.printLine(true, false)
+	defer t.lock()()
+	if t.live {
+		t.renderLive()
+		return
 	}
-	t.printHeading()
-	if t.autoMergeCells {
+	t.render()
+}
+
+// RenderPage Render a page of rows
+// Pages hold pageSize rows each and are numbered from 1. The header and
+// footer are printed with every page, and column widths are those of the
+// whole table so that pages line up.
+func (t *Table) RenderPage(page, pageSize int) error {
+	if page < 1 || pageSize < 1 {
+		return fmt.Errorf("invalid page %d of size %d", page, pageSize)
+	}
+	defer t.lock()()
+	t.pageStart, t.pageSize = (page-1)*pageSize, pageSize
+	defer func() { t.pageStart, t.pageSize = 0, 0 }()
+	t.render()
+	return nil
+}
+
+// NumPages returns the number of pages of pageSize rows RenderPage can print.
+func (t *Table) NumPages(pageSize int) int {
+	if pageSize < 1 {
+		return 0
+	}
+	defer t.lock()()
+	return (len(t.visibleRows()) + pageSize - 1) / pageSize
+}
+
+func (t *Table) render() {
+	defer t.cacheColor()()
+	if cols := t.shownColumns(); cols != nil {
+		t.project(cols).render()
+		return
+	}
+	if t.transpose {
+		t.transposed().render()
+		return
+	}
+	if t.rtl {
+		t.mirrored().render()
+		return
+	}
+	t.applyConditionalWidths()
+	t.applyFixedWidths()
+	t.prepareTree()
+	t.prepareGroups()
+	t.detectNumericColumns()
+	t.prepareHeatMaps()
+	if chunks := t.splitChunks(); len(chunks) > 1 {
+		for i, cols := range chunks {
+			if i > 0 {
+				fmt.Fprint(t.out, t.newLine)
+			}
+			sub := t.project(cols)
+			sub.splitWide = false
+			if i > 0 {
+				sub.title = ""
+			}
+			if i < len(chunks)-1 {
+				sub.footnotes = nil
+			}
+			if t.captionPos == CAPTION_TOP {
+				sub.caption = t.caption && i == 0
+			} else {
+				sub.caption = t.caption && i == len(chunks)-1
+			}
+			sub.render()
+		}
+		return
+	}
+	defer t.fitted()()
+	t.printTop()
+	if len(t.groups) > 0 {
+		t.printGroupedRows()
+	} else if t.autoMergeCells {
 		t.printRowsMergeCells()
 	} else {
 		t.printRows()
 	}
 	if !t.rowLine && t.borders.Bottom {
-		t.printLine(false, len(t.footers) == 0)
+		t.printRowLine(true)
+	}
+	t.printBottom()
+}
+
+// printTop prints everything above the rows: the caption if on top, the
+// title or top border, the header groups and the header.
+func (t *Table) printTop() {
+	if t.caption && t.captionPos == CAPTION_TOP {
+		t.printCaption()
 	}
+	t.fitHeaderGroups()
+	if t.title != "" {
+		t.printTitle()
+	} else if t.borders.Top {
+		t.printBoundaryLine(never, t.columnBoundary, true)
+	}
+	t.printHeaderGroups()
+	t.printHeading()
+}
+
+// printBottom prints everything below the rows: the footer, the footnotes
+// and the caption if at the bottom.
+func (t *Table) printBottom() {
 	t.printFooter()
+	t.printFootnotes()
 
-	if t.caption {
+	if t.caption && t.captionPos == CAPTION_BOTTOM {
 		t.printCaption()
 	}
 }
 
+// String returns the table as Render would write it, so that a table can
+// be printed with the %s verb.
+func (t *Table) String() string {
+	s, _ := t.RenderString()
+	return s
+}
+
+// RenderString renders the table to a string whatever the writer of the
+// table, returning along with it the error reported by Err.
+func (t *Table) RenderString() (string, error) {
+	defer t.lock()()
+	buf := t.renderBuffer()
+	defer bufPool.Put(buf)
+	return buf.String(), t.err
+}
+
+// RenderTo renders the table once and writes it to every writer, e.g. the
+// terminal and a log file. It returns the first write error. With
+// ColorAuto no colors are written, the output not being a single terminal.
+func (t *Table) RenderTo(writers ...io.Writer) error {
+	defer t.lock()()
+	buf := t.renderBuffer()
+	defer bufPool.Put(buf)
+	for _, w := range writers {
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bufPool holds the buffers renders and printed lines are built in, reused
+// to save allocations on large tables.
+var bufPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// renderBuffer renders the table to a buffer from bufPool, which the
+// caller puts back when done with it.
+func (t *Table) renderBuffer() *bytes.Buffer {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	out := t.out
+	t.out = buf
+	t.render()
+	t.out = out
+	return buf
+}
+
+// renderString returns the rendered table without its final newline.
+func (t *Table) renderString() string {
+	return strings.TrimSuffix(t.String(), t.newLine)
+}
+
+// RenderRow renders a single appended row to writer using the column widths
+// of the whole table. No border or separator lines are written, which makes
+// it suitable for redrawing one row in place after the initial Render.
+// The row is laid out as Render prints it, so hidden columns, SetMaxWidth
+// and SetRTL apply; transposed tables have no rows to render on their own.
+func (t *Table) RenderRow(writer io.Writer, rowIdx int) error {
+	defer t.lock()()
+	if rowIdx < 0 || rowIdx >= len(t.lines) {
+		return fmt.Errorf("row index %d out of range", rowIdx)
+	}
+	if t.transpose {
+		return errors.New("cannot render a row of a transposed table")
+	}
+	defer t.cacheColor()()
+	l, restore := t.laidOut()
+	defer restore()
+	l.prepareHeatMaps()
+	out := l.out
+	l.out = writer
+	defer func() { l.out = out }()
+
+	l.rowPos = rowIdx
+	if l.autoMergeCells {
+		var previousLine []string
+		for _, i := range l.printedRows() {
+			if i == rowIdx {
+				break
+			}
+			previousLine = l.mergeRuns(previousLine, l.lines[i])
+		}
+		l.printRowMergeCells(writer, l.lines[rowIdx], rowIdx, previousLine)
+	} else {
+		l.printRow(l.lines[rowIdx], rowIdx)
+	}
+	return nil
+}
+
 const (
 	headerRowIdx = -1
 	footerRowIdx = -2
@@ -172,20 +502,81 @@ const (
 
 // SetHeader Set table header
 func (t *Table) SetHeader(keys []string) {
+	defer t.lock()()
+	t.baseCols = len(keys)
+	for _, c := range t.computed {
+		keys = append(keys[:len(keys):len(keys)], c.header)
+	}
 	t.colSize = len(keys)
 	for i, v := range keys {
 		lines := t.parseDimension(v, i, headerRowIdx)
 		t.headers = append(t.headers, lines)
 	}
+	t.rawHeaders = append(t.rawHeaders, keys...)
+}
+
+type computedColumn struct {
+	header string
+	value  func(row []string) string
+}
+
+// AddComputedColumn Add a column whose cells are computed from the row
+// value receives the cells of each appended row and returns the cell of the
+// new column, which follows the columns of the header. Computed columns must
+// be added before SetHeader and Append are called.
+func (t *Table) AddComputedColumn(header string, value func(row []string) string) {
+	t.computed = append(t.computed, computedColumn{header: header, value: value})
+}
+
+// SetRowTransform Set a function rewriting rows as they are appended
+// transform receives the index the row will have and its cells, and returns
+// the cells to append, which may depend on other cells of the row. It runs
+// before computed columns are added.
+func (t *Table) SetRowTransform(transform func(rowIdx int, cells []string) []string) {
+	t.rowTransform = transform
+}
+
+// transformRow returns row as rewritten by the row transform.
+func (t *Table) transformRow(rowIdx int, row []string) []string {
+	if t.rowTransform == nil {
+		return row
+	}
+	return t.rowTransform(rowIdx, row)
+}
+
+// computeRow returns row extended with the cells of the computed columns.
+func (t *Table) computeRow(row []string) []string {
+	if len(t.computed) == 0 {
+		return row
+	}
+	n := len(row)
+	if n < t.baseCols {
+		n = t.baseCols
+	}
+	cells := make([]string, n, n+len(t.computed))
+	copy(cells, row)
+	for _, c := range t.computed {
+		cells = append(cells, c.value(row))
+	}
+	return cells
 }
 
 // SetFooter Set table Footer
 func (t *Table) SetFooter(keys []string) {
+	defer t.lock()()
+	if t.totalFooter {
+		// Replace the grand totals of SetGroupSubtotals.
+		t.footers, t.rawFooters, t.totalFooter = nil, nil, false
+	}
+	if !t.checkColumns(footerRowIdx, len(keys)) {
+		return
+	}
 	//t.colSize = len(keys)
 	for i, v := range keys {
 		lines := t.parseDimension(v, i, footerRowIdx)
 		t.footers = append(t.footers, lines)
 	}
+	t.rawFooters = append(t.rawFooters, keys...)
 }
 
 // SetCaption Set table Caption
@@ -196,16 +587,89 @@ func (t *Table) SetCaption(caption bool, captionText ...string) {
 	}
 }
 
+// SetCaptionPosition Set the caption above (CAPTION_TOP) or below
+// (CAPTION_BOTTOM) the table. Default is CAPTION_BOTTOM.
+func (t *Table) SetCaptionPosition(position int) {
+	t.captionPos = position
+}
+
+// SetCaptionAlignment Set Caption Alignment within the table width
+func (t *Table) SetCaptionAlignment(align int) {
+	t.captionAlign = align
+}
+
+// SetTitle Set a title printed above the header in a row spanning all
+// columns, inside the top border
+func (t *Table) SetTitle(title string) {
+	t.title = title
+}
+
+// SetTitleAlignment Set Title Alignment. Default is centered.
+func (t *Table) SetTitleAlignment(align int) {
+	t.titleAlign = align
+}
+
+// SetTitleColor Set the color attributes of the title
+func (t *Table) SetTitleColor(colors Colors) {
+	t.titleParams = makeSequence(colors)
+}
+
+// Footnote returns text with a numbered footnote marker, e.g. "text[1]", and
+// records note to be printed below the table. Identical notes share a number.
+func (t *Table) Footnote(text, note string) string {
+	n := 0
+	for i, v := range t.footnotes {
+		if v == note {
+			n = i + 1
+		}
+	}
+	if n == 0 {
+		t.footnotes = append(t.footnotes, note)
+		n = len(t.footnotes)
+	}
+	return fmt.Sprintf("%s[%d]", text, n)
+}
+
+// SetHeaderGroups Set headers spanning several columns, printed above the
+// table header. Groups cover the columns from left to right; columns past
+// the last group get an empty group cell.
+func (t *Table) SetHeaderGroups(groups []HeaderGroup) {
+	t.headerGroups = groups
+}
+
 // SetAutoFormatHeaders Turn header autoformatting on/off. Default is on (true).
 func (t *Table) SetAutoFormatHeaders(auto bool) {
 	t.autoFmt = auto
 }
 
+// SetVerticalHeader Print header text vertically, one character per line
+// Narrow columns, such as those of numbers, are then no wider than their
+// cells. Call before SetHeader.
+func (t *Table) SetVerticalHeader(vertical bool) {
+	t.verticalHeader = vertical
+}
+
 // SetAutoWrapText Turn automatic multiline text adjustment on/off. Default is on (true).
 func (t *Table) SetAutoWrapText(auto bool) {
 	t.autoWrap = auto
 }
 
+// SetTruncatePosition Set where cells too wide for their column are cut
+// when wrapping is off: at the end (TRUNCATE_END), keeping the start of the
+// cell, at the start (TRUNCATE_START), keeping its end as suits paths, or in
+// the middle (TRUNCATE_MIDDLE). Default is TRUNCATE_END.
+func (t *Table) SetTruncatePosition(position int) {
+	t.truncatePos = position
+}
+
+// SetTabWidth Set the distance between tab stops. Default is 8.
+// When automatic wrapping, which collapses white space, is off, tabs in
+// cells are expanded to spaces up to the next tab stop so that columns stay
+// aligned. A width of 0 leaves tabs as they are.
+func (t *Table) SetTabWidth(width int) {
+	t.tabWidth = width
+}
+
 // SetReflowDuringAutoWrap Turn automatic reflowing of multiline text when rewrapping. Default is on (true).
 func (t *Table) SetReflowDuringAutoWrap(auto bool) {
 	t.reflowText = auto
@@ -216,9 +680,165 @@ func (t *Table) SetColWidth(width int) {
 	t.mW = width
 }
 
+// SetMaxWidth Set the maximum width of the rendered table
+// Columns are shrunk and their cells re-wrapped when the table is wider.
+func (t *Table) SetMaxWidth(width int) {
+	t.maxWidth = width
+}
+
+// SetAutoTerminalWidth Limit the table to the width of the terminal it is
+// written to. When the writer is not a terminal the width set with
+// SetMaxWidth, if any, applies.
+func (t *Table) SetAutoTerminalWidth(auto bool) {
+	t.autoTermWidth = auto
+}
+
 // SetColMinWidth Set the minimal width for a column
+// The column keeps this width even when its content is narrower and is not
+// shrunk below it to fit SetMaxWidth.
 func (t *Table) SetColMinWidth(column int, width int) {
-	t.cs[column] = width
+	t.minWidths[column] = width
+	if t.cs[column] < width {
+		t.cs[column] = width
+	}
+}
+
+// SetConditionalColMinWidth Set the minimal width for a column when any of
+// its cells matches the given expression
+// Rules are evaluated over all rows when rendering.
+func (t *Table) SetConditionalColMinWidth(column int, matcher *regexp.Regexp, width int) {
+	t.condMinWidths[column] = append(t.condMinWidths[column], conditionalWidth{matcher, width})
+}
+
+// SetSanitizer Set the function used to clean data cells before they are
+// added to the table. Default is StripControl; nil disables sanitizing.
+func (t *Table) SetSanitizer(sanitizer func(string) string) {
+	t.sanitizer = sanitizer
+}
+
+// SetColFixedWidths Set exact widths for columns, keyed by column index
+// Content is wrapped to the width, or truncated when automatic wrapping is
+// off, so that tables rendered one after another line up.
+func (t *Table) SetColFixedWidths(widths map[int]int) {
+	for column, width := range widths {
+		t.fixedWidths[column] = width
+	}
+}
+
+// SetColShrinkPriority Set the order in which columns shrink to fit
+// SetMaxWidth. Columns with a higher priority shrink first, down to their
+// minimal width, before columns with a lower priority are touched. All
+// columns have priority 0 by default.
+func (t *Table) SetColShrinkPriority(column int, priority int) {
+	t.shrinkPriority[column] = priority
+}
+
+// SetMaxRows Set the maximum number of rows to print
+// Rows beyond the limit are replaced by a single "... and N more rows" row.
+// Default is 0, printing all rows.
+func (t *Table) SetMaxRows(rows int) {
+	t.maxRows = rows
+}
+
+// SetHeaderMaxLines Set the maximum number of lines of a header cell
+// Lines beyond the limit are dropped and the last line ends with the line
+// ellipsis. Default is 0, no limit. The limit applies to cells already
+// added as well.
+func (t *Table) SetHeaderMaxLines(lines int) {
+	defer t.lock()()
+	t.hMaxLines = lines
+	t.reparse()
+}
+
+// SetFooterMaxLines Set the maximum number of lines of a footer cell
+func (t *Table) SetFooterMaxLines(lines int) {
+	defer t.lock()()
+	t.fMaxLines = lines
+	t.reparse()
+}
+
+// SetWidthCache Does nothing
+// Deprecated: plain ASCII text is measured without allocating and a shared
+// cache of other text is no longer kept.
+func (t *Table) SetWidthCache(cache bool) {}
+
+// SetMaxLines Set the maximum number of lines of a row cell
+// The limit applies to rows already appended as well.
+func (t *Table) SetMaxLines(lines int) {
+	defer t.lock()()
+	t.maxLines = lines
+	t.reparse()
+}
+
+// SetEllipsis Set the text marking a cell cut to fit its column
+// Cells are cut when wrapping is off. Default is ELLIPSIS, an empty string
+// cuts cells without a mark.
+func (t *Table) SetEllipsis(ellipsis string) {
+	t.ellipsis = ellipsis
+}
+
+// SetColumnEllipsis Set the text marking a cut cell of a column
+func (t *Table) SetColumnEllipsis(column int, ellipsis string) {
+	t.colEllipsis[column] = ellipsis
+}
+
+// ellipsisFor returns the text marking a cut cell of column col.
+func (t *Table) ellipsisFor(col int) string {
+	if ellipsis, ok := t.colEllipsis[col]; ok {
+		return ellipsis
+	}
+	return t.ellipsis
+}
+
+// autoTitle auto formats text of a header or footer cell of column col, keeping
+// the ellipses marking a cell cut to its width or to its maximum lines.
+func (t *Table) autoTitle(section, col int, text string) string {
+	var marks []string
+	if !t.autoWrap {
+		marks = append(marks, t.ellipsisFor(col))
+	}
+	rowKey := headerRowIdx
+	if section == SectionFooter {
+		rowKey = footerRowIdx
+	}
+	if t.maxLinesFor(rowKey) > 0 {
+		marks = append(marks, t.lineEllipsis)
+	}
+	return titleAround(text, marks)
+}
+
+// titleAround applies Title to the text between the marks in s.
+func titleAround(s string, marks []string) string {
+	for i, mark := range marks {
+		if mark == "" || !strings.Contains(s, mark) {
+			continue
+		}
+		parts := strings.Split(s, mark)
+		for j, part := range parts {
+			// Keep the spaces next to the marks, which Title trims.
+			trimmed := strings.TrimSpace(part)
+			if trimmed == "" {
+				continue
+			}
+			k := strings.Index(part, trimmed)
+			parts[j] = part[:k] + titleAround(trimmed, marks[i+1:]) + part[k+len(trimmed):]
+		}
+		return strings.Join(parts, mark)
+	}
+	return Title(s)
+}
+
+// SetLineEllipsis Set the text marking a cell cut by a maximum number of lines
+func (t *Table) SetLineEllipsis(ellipsis string) {
+	t.lineEllipsis = ellipsis
+}
+
+// SetSplitWideTable Split a table wider than the maximum width into several
+// tables printed one below the other, each holding as many columns as fit.
+// The key column, if not -1, is repeated as the first column of every table.
+func (t *Table) SetSplitWideTable(split bool, keyColumn int) {
+	t.splitWide = split
+	t.splitKey = keyColumn
 }
 
 // SetColumnSeparator Set the Column Separator
@@ -281,7 +901,9 @@ func (t *Table) SetColumnAlignment(keys []int) {
 	}
 }
 
-// SetNewLine Set New Line
+// SetNewLine Set the sequence ending every printed line. Default is NEWLINE,
+// "\r\n" suits Windows logs. Line breaks in cells may be either "\n" or
+// "\r\n" whatever the sequence.
 func (t *Table) SetNewLine(nl string) {
 	t.newLine = nl
 }
@@ -318,6 +940,40 @@ func (t *Table) SetAutoMergeCellsByColumnIndex(cols []int) {
 	}
 }
 
+// SetNoMergeColumns Exclude columns from auto merge
+// Identical cells in these columns are never merged, whichever columns
+// merging is otherwise enabled for.
+func (t *Table) SetNoMergeColumns(cols ...int) {
+	t.noMergeColumns = make(map[int]bool)
+	for _, col := range cols {
+		t.noMergeColumns[col] = true
+	}
+}
+
+// SetKeepSpaceColumns Keep the white space of cells of columns
+// Wrapping words collapses runs of spaces and drops indentation. Cells of
+// these columns are not wrapped by words, lines too wide for the column are
+// split instead, so that indentation, e.g. of code or file trees, survives
+// in these columns alone.
+func (t *Table) SetKeepSpaceColumns(cols ...int) {
+	t.keepSpaceColumns = make(map[int]bool)
+	for _, col := range cols {
+		t.keepSpaceColumns[col] = true
+	}
+}
+
+// SetMergeComparator Set the function deciding whether two cells are identical
+// for auto merge, e.g. strings.EqualFold for case insensitive merging.
+func (t *Table) SetMergeComparator(equal func(a, b string) bool) {
+	t.mergeCompare = equal
+}
+
+// SetColumnMergeComparator Set the merge comparator of a single column
+// This takes precedence over the comparator set by SetMergeComparator.
+func (t *Table) SetColumnMergeComparator(column int, equal func(a, b string) bool) {
+	t.colMergeCompare[column] = equal
+}
+
 // SetBorder Set Table Border
 // This would enable / disable line around the table
 // Deprecated: use EnableBorder
@@ -407,7 +1063,19 @@ func (t *Table) SetStructs(v interface{}) error {
 				if f.Kind() == reflect.Ptr {
 					f = f.Elem()
 				}
+				if format := t.colFormatters[j]; format != nil {
+					if f.IsValid() {
+						rows[j] = format(f.Interface())
+					} else {
+						rows[j] = format(nil)
+					}
+					continue
+				}
 				if f.IsValid() {
+					if tm, ok := f.Interface().(time.Time); ok {
+						rows[j] = t.formatTime(j, tm)
+						continue
+					}
 					if s, ok := f.Interface().(fmt.Stringer); ok {
 						rows[j] = s.String()
 						continue
@@ -427,6 +1095,16 @@ func (t *Table) SetStructs(v interface{}) error {
 
 // Append row to table
 func (t *Table) Append(row []string) {
+	defer t.lock()()
+	t.append(row)
+}
+
+// append appends row, returning false when strict mode rejects it.
+func (t *Table) append(row []string) bool {
+	row = t.computeRow(t.transformRow(len(t.lines), row))
+	if !t.checkColumns(len(t.lines), len(row)) {
+		return false
+	}
 	rowSize := len(t.headers)
 	if rowSize > t.colSize {
 		t.colSize = rowSize
@@ -434,7 +1112,10 @@ func (t *Table) Append(row []string) {
 
 	n := len(t.lines)
 	line := [][]string{}
+	raw := make([]string, len(row))
 	for i, v := range row {
+		v = t.cleanCell(i, v)
+		raw[i] = v
 
 		// Detect string  width
 		// Detect String height
@@ -445,132 +1126,578 @@ func (t *Table) Append(row []string) {
 		line = append(line, out)
 	}
 	t.lines = append(t.lines, line)
+	t.rows = append(t.rows, raw)
+	return true
 }
 
-// Rich Append row to table with color attributes
-func (t *Table) Rich(row []string, colors []Colors) {
-	rowSize := len(t.headers)
-	if rowSize > t.colSize {
-		t.colSize = rowSize
+// cleanCell returns cell v of column i as stored, sanitized, replaced by
+// its icon and with its digits grouped.
+func (t *Table) cleanCell(i int, v string) string {
+	if t.sanitizer != nil {
+		v = t.sanitizer(v)
 	}
+	if icon, ok := t.colIcons[i][v]; ok {
+		v = icon
+	}
+	if f, ok := t.numberFormats[i]; ok {
+		v = GroupDigits(v, f.thousands, f.decimal)
+	}
+	return v
+}
 
-	n := len(t.lines)
-	line := [][]string{}
-	for i, v := range row {
+// SetStrict Reject rows and footers whose number of cells differs from
+// that of the header instead of printing them padded or cut. The first
+// rejected row or footer is reported by Err.
+func (t *Table) SetStrict(strict bool) {
+	t.strict = strict
+}
 
-		// Detect string  width
-		// Detect String height
-		// Break strings into words
-		out := t.parseDimension(v, i, n)
+// Err returns the first error met while adding rows or a footer in strict
+// mode, or nil.
+func (t *Table) Err() error {
+	defer t.lock()()
+	return t.err
+}
 
-		if len(colors) > i {
-			color := colors[i]
-			out[0] = format(out[0], color)
-		}
+// checkColumns reports whether row rowKey, or the footer, of n cells may be
+// added, recording an error when it may not.
+func (t *Table) checkColumns(rowKey, n int) bool {
+	if !t.strict || len(t.headers) == 0 || n == len(t.headers) {
+		return true
+	}
+	if t.err == nil && rowKey == footerRowIdx {
+		t.err = fmt.Errorf("footer has %d cells, want %d", n, len(t.headers))
+	} else if t.err == nil {
+		t.err = fmt.Errorf("row %d has %d cells, want %d", rowKey, n, len(t.headers))
+	}
+	return false
+}
 
-		// Append broken words
-		line = append(line, out)
+// Cell is a row cell with its own alignment and color attributes, which
+// override those of its column. An Align of ALIGN_DEFAULT keeps the column
+// alignment and empty Colors keep the column colors. A Table, if set, is
+// rendered as the content of the cell in place of Text, without wrapping.
+type Cell struct {
+	Text   string
+	Align  int
+	Colors Colors
+	Table  *Table
+}
+
+// AppendCells Append row to table with per cell alignment and colors
+func (t *Table) AppendCells(cells []Cell) {
+	row := make([]string, len(cells))
+	defer t.lock()()
+	for i, c := range cells {
+		row[i] = c.Text
+		if c.Table != nil {
+			row[i] = c.Table.renderString()
+			t.nestedCells[[2]int{len(t.lines), i}] = true
+		}
+	}
+	n := len(t.lines)
+	t.cellStyles[n] = cells
+	if !t.append(row) {
+		delete(t.cellStyles, n)
+		for i := range cells {
+			delete(t.nestedCells, [2]int{n, i})
+		}
 	}
-	t.lines = append(t.lines, line)
 }
 
-// AppendBulk Allow Support for Bulk Append
-// Eliminates repeated for loops
-func (t *Table) AppendBulk(rows [][]string) {
-	for _, row := range rows {
-		t.Append(row)
+// RowStyle is the alignment and colors of every cell of a row, overriding
+// those of the columns as for Cell.
+type RowStyle struct {
+	Align  int
+	Colors Colors
+}
+
+// AppendStyled Append row to table with a row wide style
+func (t *Table) AppendStyled(row []string, style RowStyle) {
+	cells := make([]Cell, len(row))
+	for i, v := range row {
+		cells[i] = Cell{Text: v, Align: style.Align, Colors: style.Colors}
 	}
+	t.AppendCells(cells)
 }
 
-// NumLines to get the number of lines
-func (t *Table) NumLines() int {
-	return len(t.lines)
+// AppendSeparator Draw a line below the last appended row
+// The line is drawn even when SetRowLine is not set, to set logical groups
+// of rows apart.
+func (t *Table) AppendSeparator() {
+	defer t.lock()()
+	if len(t.rows) > 0 {
+		t.separators[len(t.rows)-1] = true
+	}
 }
 
-// ClearRows Clear rows
-func (t *Table) ClearRows() {
-	t.lines = [][][]string{}
+// rowDivider is a labelled line printed above a row.
+type rowDivider struct {
+	label string
+	style RowStyle
 }
 
-// ClearFooter Clear footer
-func (t *Table) ClearFooter() {
-	t.footers = [][]string{}
+// AppendDivider Append a labelled line spanning all columns
+// The divider is printed above the next appended row, with the label placed
+// by style.Align within the row separator, e.g. "--- Production ---", and
+// colored by style.Colors. Dividers are left out when rows are grouped.
+func (t *Table) AppendDivider(label string, style RowStyle) {
+	defer t.lock()()
+	t.dividers[len(t.rows)] = append(t.dividers[len(t.rows)], rowDivider{label, style})
 }
 
-// Center based on position and border.
-func (t *Table) center(i int, isFirstRow, isLastRow bool) string {
-	if i == -1 {
-		if !t.borders.Left {
-			return t.syms[symEW]
+// printDividers prints the dividers above row rowIdx, lined tells whether
+// a line was just printed.
+func (t *Table) printDividers(rowIdx int, lined bool) {
+	for _, d := range t.dividers[rowIdx] {
+		if !lined {
+			t.printBoundaryLine(t.columnSeparator, never, false)
 		}
-		if isFirstRow {
-			return t.syms[symES]
-		}
-		if isLastRow {
-			return t.syms[symNE]
-		}
-		return t.syms[symNES]
-	}
-
-	if i == len(t.cs)-1 {
-		if !t.borders.Right {
-			return t.syms[symEW]
+		lined = true
+		fill := t.syms[symEW]
+		label := d.label
+		if label != "" {
+			label = SPACE + label + SPACE
 		}
-		if isFirstRow {
-			return t.syms[symSW]
+		switch d.style.Align {
+		case ALIGN_LEFT:
+			label = strings.Repeat(fill, 2) + label
+		case ALIGN_RIGHT:
+			label += strings.Repeat(fill, 2)
 		}
-		if isLastRow {
-			return t.syms[symNW]
+		params := makeSequence(d.style.Colors)
+		if width := t.spanningWidth(); DisplayWidth(label) <= width {
+			t.printBandLine(t.dividerLine(pad(d.style.Align)(label, fill, width), d.label, params))
+		} else {
+			t.printSpanningRow(label, d.style.Align, params, fill)
 		}
-		return t.syms[symNSW]
+		t.printBoundaryLine(never, t.columnSeparator, false)
 	}
+}
 
-	if isFirstRow {
-		return t.syms[symESW]
+// dividerLine colors line, a divider holding label padded with the border
+// fill, with params, leaving the fill to the border color if one is set.
+func (t *Table) dividerLine(line, label, params string) string {
+	if !t.colorEnabled() {
+		return line
 	}
-	if isLastRow {
-		return t.syms[symNEW]
+	if t.borderParams == "" {
+		return ConditionString(params == "", line, format(line, params))
 	}
-	return t.syms[symNESW]
+	i := strings.Index(line, label)
+	if label == "" || i < 0 {
+		return t.border(line)
+	}
+	rest := line[i+len(label):]
+	if params != "" {
+		label = format(label, params)
+	}
+	return t.border(line[:i]) + label + t.border(rest)
 }
 
-// Print line based on row width
-func (t *Table) printLine(isFirst, isLast bool) {
-	fmt.Fprint(t.out, t.center(-1, isFirst, isLast))
-	for i := 0; i < len(t.cs); i++ {
-		v := t.cs[i]
-		fmt.Fprintf(t.out, "%s%s%s%s",
-			t.syms[symEW],
-			strings.Repeat(t.syms[symEW], v),
-			t.syms[symEW],
-			t.center(i, isFirst, isLast))
+// cellAlign returns the alignment of column y in row rowIdx.
+func (t *Table) cellAlign(rowIdx, y int) int {
+	if cells := t.cellStyles[rowIdx]; y < len(cells) && cells[y].Align != ALIGN_DEFAULT {
+		return cells[y].Align
 	}
-	fmt.Fprint(t.out, t.newLine)
+	if t.columnsAlign[y] == ALIGN_DEFAULT && t.numAlignRows {
+		return t.numericAlign(y)
+	}
+	return t.columnsAlign[y]
+}
+
+// rowColor formats line x of column y in row rowIdx with the color given to
+// the cell by Rich, which applies to the first line of the cell.
+func (t *Table) rowColor(rowIdx, y, x int, line string) string {
+	if colors := t.rowColors[rowIdx]; x == 0 && y < len(colors) && t.colorEnabled() {
+		return format(line, colors[y])
+	}
+	return line
+}
+
+// cellParams returns the color sequence of column y in row rowIdx, or an
+// empty string when the cell has no colors of its own.
+func (t *Table) cellParams(rowIdx, y int) string {
+	if cells := t.cellStyles[rowIdx]; y < len(cells) && len(cells[y].Colors) > 0 && t.colorEnabled() {
+		return makeSequence(cells[y].Colors)
+	}
+	if colors := t.heatColors(rowIdx, y); colors != nil && t.colorEnabled() {
+		return makeSequence(colors)
+	}
+	return ""
+}
+
+// Rich Append row to table with color attributes
+func (t *Table) Rich(row []string, colors []Colors) {
+	defer t.lock()()
+	n := len(t.lines)
+	if t.append(row) {
+		t.rowColors[n] = colors
+	}
+}
+
+// AppendBulk Allow Support for Bulk Append
+// Eliminates repeated for loops
+func (t *Table) AppendBulk(rows [][]string) {
+	if t.workers > 1 {
+		defer t.lock()()
+		t.appendParallel(rows)
+		return
+	}
+	for _, row := range rows {
+		t.Append(row)
+	}
+}
+
+// AppendFrom Append rows received from ch until it is closed
+// It returns the context error if ctx is done before ch is closed; rows
+// received up to that point stay appended.
+func (t *Table) AppendFrom(ctx context.Context, ch <-chan []string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case row, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			t.Append(row)
+		}
+	}
+}
+
+// AppendAny Append row of arbitrary values
+// Each value is formatted by the formatter of its column if one is set with
+// SetColumnFormatter. Otherwise nil becomes an empty cell, a fmt.Stringer
+// its String and anything else is formatted with fmt.Sprint.
+func (t *Table) AppendAny(row []interface{}) {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		cells[i] = t.formatValue(i, v)
+	}
+	t.Append(cells)
+}
+
+// SetColumnFormatter Set the function formatting values of a column
+// It is used by AppendAny, AppendMap and SetStructs.
+func (t *Table) SetColumnFormatter(column int, format func(interface{}) string) {
+	t.colFormatters[column] = format
+}
+
+// TimeRelative is a time layout printing times relative to now like
+// RelativeTime, e.g. "5 minutes ago" or "in 2 hours".
+const TimeRelative = "relative"
+
+// SetTimeLayout Set the layout of time.Time values
+// layout is a time.Format layout or TimeRelative. Times are printed with
+// their String method by default.
+func (t *Table) SetTimeLayout(layout string) {
+	t.timeLayout = layout
+}
+
+// SetColumnTimeLayout Set the layout of time.Time values of a column
+// This takes precedence over the layout set by SetTimeLayout.
+func (t *Table) SetColumnTimeLayout(column int, layout string) {
+	t.colTimeLayouts[column] = layout
+}
+
+// formatTime formats tm as a cell of column col.
+func (t *Table) formatTime(col int, tm time.Time) string {
+	layout, ok := t.colTimeLayouts[col]
+	if !ok {
+		layout = t.timeLayout
+	}
+	switch layout {
+	case "":
+		return tm.String()
+	case TimeRelative:
+		return humanTime(tm, t.now(), relativeLayout)
+	}
+	return tm.Format(layout)
+}
+
+type numberFormat struct {
+	thousands, decimal string
+}
+
+// SetColumnNumberFormat Group the digits of numbers in a column
+// Plain numbers appended to the column are formatted with GroupDigits using
+// the thousands separator and decimal point of the desired locale, e.g.
+// "," and "." for en-US or "." and "," for de-DE. Numbers using separators
+// other than "," and "." are not detected as numbers for automatic right
+// alignment, so such columns should be aligned with SetColumnAlignment.
+func (t *Table) SetColumnNumberFormat(column int, thousands, decimal string) {
+	t.numberFormats[column] = numberFormat{thousands: thousands, decimal: decimal}
+}
+
+// SetColumnIcons Replace values of a column by icons
+// Cells appended to the column that equal a key of icons are replaced by
+// its value, e.g. map[string]string{"ok": "✓", "fail": "✗"}; other cells
+// are kept as they are. Icons are sized by their display width.
+func (t *Table) SetColumnIcons(column int, icons map[string]string) {
+	t.colIcons[column] = icons
+}
+
+// formatValue formats v as a cell of column col.
+func (t *Table) formatValue(col int, v interface{}) string {
+	if format := t.colFormatters[col]; format != nil {
+		return format(v)
+	}
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return t.formatTime(col, v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// AppendMap Append row whose cells are keyed by header name
+// Values are formatted as by AppendAny and missing keys become empty cells.
+// Keys not matching any header are ignored unless SetStrictMapKeys is
+// enabled, in which case an error is returned and nothing is appended.
+func (t *Table) AppendMap(row map[string]interface{}) error {
+	// Computed columns are added by Append.
+	cells := make([]string, t.baseCols)
+	for k, v := range row {
+		i := t.headerIndex(k)
+		if i < 0 || i >= len(cells) {
+			if t.strictMapKeys {
+				return fmt.Errorf("unknown column %q", k)
+			}
+			continue
+		}
+		cells[i] = t.formatValue(i, v)
+	}
+	t.Append(cells)
+	return nil
+}
+
+// SetStrictMapKeys Set whether AppendMap rejects unknown keys
+func (t *Table) SetStrictMapKeys(strict bool) {
+	t.strictMapKeys = strict
+}
+
+// Row is a row whose cells are set by header name, see NewRow.
+type Row struct {
+	t     *Table
+	cells []string
+}
+
+// NewRow Start a new row with a cell per header
+// The row is appended with AppendRow once its cells are set. Cells of
+// computed columns are added when it is appended.
+func (t *Table) NewRow() *Row {
+	return &Row{t: t, cells: make([]string, t.baseCols)}
+}
+
+// Set Set the cell of the column whose header is column
+func (r *Row) Set(column, value string) error {
+	i := r.t.headerIndex(column)
+	if i < 0 {
+		return fmt.Errorf("unknown column %q", column)
+	}
+	if i >= len(r.cells) {
+		return fmt.Errorf("column %q is computed", column)
+	}
+	r.cells[i] = value
+	return nil
+}
+
+// AppendRow Append row started with NewRow
+func (t *Table) AppendRow(row *Row) {
+	t.Append(row.cells)
+}
+
+// headerIndex returns the column of the header named name, or -1.
+func (t *Table) headerIndex(name string) int {
+	for i, h := range t.rawHeaders {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// NumLines to get the number of lines
+func (t *Table) NumLines() int {
+	defer t.lock()()
+	return len(t.lines)
+}
+
+// RowCount returns the number of rows appended, like NumLines.
+func (t *Table) RowCount() int {
+	return t.NumLines()
+}
+
+// Headers returns the cells of the header as set, before formatting.
+func (t *Table) Headers() []string {
+	defer t.lock()()
+	return append([]string(nil), t.rawHeaders...)
+}
+
+// Rows returns the cells of the rows in the order they were appended, as
+// stored before wrapping.
+func (t *Table) Rows() [][]string {
+	defer t.lock()()
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = append([]string(nil), row...)
+	}
+	return rows
+}
+
+// SetThreadSafe Guard the table with a mutex
+// When enabled, appending rows, setting the header or footer, clearing and
+// rendering may be called from multiple goroutines. Enable it before the
+// table is shared.
+func (t *Table) SetThreadSafe(safe bool) {
+	if !safe {
+		t.mu = nil
+	} else if t.mu == nil {
+		t.mu = new(sync.Mutex)
+	}
+}
+
+// lock locks the table in thread safe mode and returns the matching unlock.
+func (t *Table) lock() func() {
+	if t.mu == nil {
+		return func() {}
+	}
+	t.mu.Lock()
+	return t.mu.Unlock
+}
+
+// ClearRows Clear rows
+func (t *Table) ClearRows() {
+	defer t.lock()()
+	t.clearRows()
+}
+
+func (t *Table) clearRows() {
+	t.lines = [][][]string{}
+	t.rows = [][]string{}
+	t.rowColors = make(map[int][]Colors)
+	t.cellStyles = make(map[int][]Cell)
+	t.treeDepths = make(map[int]int)
+	t.nestedCells = make(map[[2]int]bool)
+	t.separators = make(map[int]bool)
+	t.dividers = make(map[int][]rowDivider)
+}
+
+// ClearFooter Clear footer
+func (t *Table) ClearFooter() {
+	defer t.lock()()
+	t.clearFooter()
+}
+
+func (t *Table) clearFooter() {
+	t.footers = [][]string{}
+	t.rawFooters = nil
+	t.totalFooter = false
+}
+
+// ClearHeader Clear header
+func (t *Table) ClearHeader() {
+	defer t.lock()()
+	t.clearHeader()
+}
+
+func (t *Table) clearHeader() {
+	t.headers = [][]string{}
+	t.rawHeaders = nil
+	t.baseCols = 0
+}
+
+// Reset Clear the header, rows and footer along with the column widths
+// they set, so that the table can print a new data set with the same
+// settings.
+func (t *Table) Reset() {
+	defer t.lock()()
+	t.clearHeader()
+	t.clearRows()
+	t.clearFooter()
+	t.err = nil
+	t.colSize = -1
+	t.cs = make(map[int]int)
+	for col, width := range t.minWidths {
+		t.cs[col] = width
+	}
+	t.rs = make(map[int]int)
+}
+
+// Center based on position and border.
+func (t *Table) center(i int, isFirstRow, isLastRow bool) string {
+	if i == -1 {
+		if !t.borders.Left {
+			return t.syms[symEW]
+		}
+		if isFirstRow {
+			return t.syms[symES]
+		}
+		if isLastRow {
+			return t.syms[symNE]
+		}
+		return t.syms[symNES]
+	}
+
+	if i == len(t.cs)-1 {
+		if !t.borders.Right {
+			return t.syms[symEW]
+		}
+		if isFirstRow {
+			return t.syms[symSW]
+		}
+		if isLastRow {
+			return t.syms[symNW]
+		}
+		return t.syms[symNSW]
+	}
+
+	if !t.columnSeparator(i) {
+		return t.syms[symEW]
+	}
+	if isFirstRow {
+		return t.syms[symESW]
+	}
+	if isLastRow {
+		return t.syms[symNEW]
+	}
+	return t.syms[symNESW]
+}
+
+// Print line based on row width
+func (t *Table) printLine(isFirst, isLast bool) {
+	line := t.center(-1, isFirst, isLast)
+	for i := 0; i < len(t.cs); i++ {
+		v := t.cs[i]
+		line += strings.Repeat(t.syms[symEW], v+2) + t.center(i, isFirst, isLast)
+	}
+	fmt.Fprint(t.out, t.border(line), t.newLine)
 }
 
 // Print line based on row width with our without cell separator
 func (t *Table) printLineOptionalCellSeparators(nl bool, displayCellSeparator []bool) {
-	fmt.Fprint(t.out, t.syms[symNES])
+	line := t.syms[symNES]
 	centerSym := symNESW
 	for i := 0; i < len(t.cs); i++ {
 		v := t.cs[i]
 		if i == len(t.cs)-1 {
 			centerSym = symNSW
+		} else if !t.columnSeparator(i) {
+			centerSym = symEW
 		}
 		if i > len(displayCellSeparator) || displayCellSeparator[i] {
 			// Display the cell separator
-			fmt.Fprintf(t.out, "%s%s%s%s",
-				t.syms[symEW],
-				strings.Repeat(string(t.syms[symEW]), v),
-				t.syms[symEW],
-				t.syms[centerSym])
+			line += strings.Repeat(t.syms[symEW], v+2) + t.syms[centerSym]
 		} else {
 			// Don't display the cell separator for this cell
-			fmt.Fprintf(t.out, "%s%s",
-				strings.Repeat(" ", v+2),
-				t.syms[centerSym])
+			line += strings.Repeat(" ", v+2) + t.syms[centerSym]
 		}
 	}
+	fmt.Fprint(t.out, t.border(line))
 	if nl {
 		fmt.Fprint(t.out, t.newLine)
 	}
@@ -589,22 +1716,117 @@ func pad(align int) func(string, string, int) string {
 	return padFunc
 }
 
+// SetNumericColumnAlignment Align columns holding only numbers to the right
+// For each enabled section, cells of columns whose rows are all numbers are
+// aligned right and cells of other columns left, unless an alignment is set
+// for the section or column. Empty cells are ignored.
+func (t *Table) SetNumericColumnAlignment(header, rows, footer bool) {
+	t.numAlignHeader = header
+	t.numAlignRows = rows
+	t.numAlignFooter = footer
+}
+
+// detectNumericColumns records the columns whose rows are all numbers.
+func (t *Table) detectNumericColumns() {
+	t.numericCols = make(map[int]bool)
+	if !t.numAlignHeader && !t.numAlignRows && !t.numAlignFooter {
+		return
+	}
+	for y, align := range sniffAlignment(t.rows) {
+		t.numericCols[y] = align == ALIGN_RIGHT
+	}
+}
+
+// numericAlign returns the alignment of column y by its numeric type.
+func (t *Table) numericAlign(y int) int {
+	if t.numericCols[y] {
+		return ALIGN_RIGHT
+	}
+	return ALIGN_LEFT
+}
+
+// sectionPad returns the pad function of column y in a section aligned by
+// align, detecting numeric columns if numeric is set.
+func (t *Table) sectionPad(align int, numeric bool, y int) func(string, string, int) string {
+	if numeric && align == ALIGN_DEFAULT {
+		return pad(t.numericAlign(y))
+	}
+	return pad(align)
+}
+
+// Table sections
+const (
+	SectionHeader = iota
+	SectionRow
+	SectionFooter
+)
+
+// CellContext describes a cell being rendered.
+type CellContext struct {
+	// Section is SectionHeader, SectionRow or SectionFooter.
+	Section int
+	// Row is the index of the row as appended, or -1 outside of rows.
+	Row int
+	// Column is the index of the column.
+	Column int
+	// Value is the cell as rendered, its lines joined by newlines.
+	Value string
+	// Width is the width of the column.
+	Width int
+	// Line is the index of the line within the cell given to a cell hook.
+	Line int
+}
+
+// SetCellCallback Set a function called with every cell as it is rendered
+func (t *Table) SetCellCallback(callback func(CellContext)) {
+	t.cellCallback = callback
+}
+
+// SetCellHook Set a function rewriting cells right before they are written
+// hook receives each line of a cell fully formatted, padded to the column
+// width and colored, and returns the text to write in its place, which
+// should keep the same display width. ctx.Value is the unformatted line.
+func (t *Table) SetCellHook(hook func(ctx CellContext, cell string) string) {
+	t.cellHook = hook
+}
+
+// drawCell returns cell as rewritten by the cell hook.
+func (t *Table) drawCell(section, rowIdx, col, line int, value, cell string) string {
+	if t.cellHook == nil {
+		return cell
+	}
+	ctx := CellContext{Section: section, Row: rowIdx, Column: col, Value: value, Width: t.cs[col], Line: line}
+	return t.cellHook(ctx, cell)
+}
+
+// notifyCells calls the cell callback for the cells of a row.
+func (t *Table) notifyCells(section, rowIdx int, columns [][]string) {
+	if t.cellCallback == nil {
+		return
+	}
+	for y, lines := range columns {
+		value := strings.Join(lines, "\n")
+		if section != SectionRow && t.autoFmt {
+			value = t.autoTitle(section, y, value)
+		}
+		t.cellCallback(CellContext{Section: section, Row: rowIdx, Column: y, Value: value, Width: t.cs[y]})
+	}
+}
+
 // Print heading information
 func (t *Table) printHeading() {
 	// Check if headers is available
 	if len(t.headers) < 1 {
 		return
 	}
+	t.notifyCells(SectionHeader, -1, t.headers)
 
 	// Identify last column
 	end := len(t.cs) - 1
 
-	// Get pad function
-	padFunc := pad(t.hAlign)
-
 	// Checking for ANSI escape sequences for header
 	is_esc_seq := false
-	if len(t.headerParams) > 0 {
+	if len(t.headerParams) > 0 && t.colorEnabled() {
 		is_esc_seq = true
 	}
 
@@ -616,226 +1838,1186 @@ func (t *Table) printHeading() {
 		// Check if border is set
 		// Replace with space if not set
 		if !t.noWhiteSpace {
-			fmt.Fprint(t.out, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
+			fmt.Fprint(t.out, ConditionString(t.borders.Left, t.border(t.syms[symNS]), SPACE))
 		}
 
 		for y := 0; y <= end; y++ {
 			v := t.cs[y]
 			h := ""
+			padFunc := t.sectionPad(t.hAlign, t.numAlignHeader, y)
 
 			if y < len(t.headers) && x < len(t.headers[y]) {
 				h = t.headers[y][x]
 			}
 			if t.autoFmt {
-				h = Title(h)
+				h = t.autoTitle(SectionHeader, y, h)
 			}
-			pad := ConditionString((y == end && !t.borders.Left), SPACE, t.syms[symNS])
+			pad := ConditionString((y == end && !t.borders.Left) || !t.columnSeparator(y), SPACE, t.border(t.syms[symNS]))
 			if t.noWhiteSpace {
 				pad = ConditionString((y == end && !t.borders.Left), SPACE, t.tablePadding)
 			}
+			cell := padFunc(h, SPACE, v)
 			if is_esc_seq {
-				if !t.noWhiteSpace {
-					fmt.Fprintf(t.out, " %s %s",
-						format(padFunc(h, SPACE, v),
-							t.headerParams[y]), pad)
-				} else {
-					fmt.Fprintf(t.out, "%s %s",
-						format(padFunc(h, SPACE, v),
-							t.headerParams[y]), pad)
-				}
+				cell = format(cell, t.headerParams[y])
+			}
+			cell = t.drawCell(SectionHeader, -1, y, x, h, cell)
+			if !t.noWhiteSpace {
+				fmt.Fprintf(t.out, " %s %s", cell, pad)
+			} else if is_esc_seq {
+				fmt.Fprintf(t.out, "%s %s", cell, pad)
 			} else {
-				if !t.noWhiteSpace {
-					fmt.Fprintf(t.out, " %s %s",
-						padFunc(h, SPACE, v),
-						pad)
-				} else {
-					// the spaces between breaks the kube formatting
-					fmt.Fprintf(t.out, "%s%s",
-						padFunc(h, SPACE, v),
-						pad)
-				}
+				// the spaces between breaks the kube formatting
+				fmt.Fprintf(t.out, "%s%s", cell, pad)
+			}
+		}
+		// Next line
+		fmt.Fprint(t.out, t.newLine)
+	}
+	if t.hdrLine {
+		t.sectionLine(SectionHeader, func() { t.printLine(false, false) })
+	}
+}
+
+// Print heading information
+func (t *Table) printFooter() {
+	// Check if headers is available
+	if len(t.footers) < 1 {
+		return
+	}
+
+	// Only print line if border is not set
+	if !t.borders.Bottom {
+		t.sectionLine(SectionFooter, func() { t.printLine(false, false) })
+	}
+
+	// Identify last column
+	end := len(t.cs) - 1
+
+	// Checking for ANSI escape sequences for header
+	is_esc_seq := false
+	if len(t.footerParams) > 0 && t.colorEnabled() {
+		is_esc_seq = true
+	}
+
+	// Maximum height.
+	max := t.rs[footerRowIdx]
+
+	// Print Footer
+	for i := 0; i < (len(t.cs) - len(t.footers)); i++ {
+		lines := t.parseDimension(" ", len(t.footers), footerRowIdx)
+		t.footers = append(t.footers, lines)
+	}
+	t.notifyCells(SectionFooter, -1, t.footers)
+	erasePad := make([]bool, len(t.footers))
+	for x := 0; x < max; x++ {
+		// Check if border is set
+		// Replace with space if not set
+		fmt.Fprint(t.out, ConditionString(t.borders.Bottom, t.border(t.syms[symNS]), SPACE))
+
+		for y := 0; y <= end; y++ {
+			v := t.cs[y]
+			f := ""
+			padFunc := t.sectionPad(t.fAlign, t.numAlignFooter, y)
+			if y < len(t.footers) && x < len(t.footers[y]) {
+				f = t.footers[y][x]
+			}
+			if t.autoFmt {
+				f = t.autoTitle(SectionFooter, y, f)
+			}
+			pad := ConditionString((y == end && !t.borders.Top) || !t.columnSeparator(y), SPACE, t.border(t.syms[symNS]))
+
+			if erasePad[y] || (x == 0 && len(f) == 0) {
+				pad = SPACE
+				erasePad[y] = true
+			}
+
+			cell := padFunc(f, SPACE, v)
+			if is_esc_seq {
+				cell = format(cell, t.footerParams[y])
+			}
+			fmt.Fprintf(t.out, " %s %s", t.drawCell(SectionFooter, -1, y, x, f, cell), pad)
+
+			//fmt.Fprintf(t.out, " %s %s",
+			//	padFunc(f, SPACE, v),
+			//	pad)
+		}
+		// Next line
+		fmt.Fprint(t.out, t.newLine)
+	}
+
+	hasPrinted := false
+	line := ""
+
+	for i := 0; i <= end; i++ {
+		v := t.cs[i]
+		pad := t.syms[symEW]
+		center := t.syms[symNEW]
+		length := len(t.footers[i][0])
+
+		if length > 0 {
+			hasPrinted = true
+		}
+
+		// Set center to be space if length is 0
+		if length == 0 && !t.borders.Right {
+			center = SPACE
+		}
+
+		// Print first junction
+		if i == 0 {
+			if length > 0 && !t.borders.Left {
+				center = t.syms[symEW]
+			} else if center != SPACE {
+				center = t.syms[symNE]
+			}
+			line += center
+		}
+
+		// Pad With space of length is 0
+		if length == 0 {
+			pad = SPACE
+		}
+		// Ignore left space as it has printed before
+		if hasPrinted || t.borders.Left {
+			pad = t.syms[symEW]
+			center = t.syms[symNEW]
+		}
+
+		// Change Center end position
+		if center != SPACE {
+			if i == end {
+				if t.borders.Right {
+					center = t.syms[symNW]
+				} else {
+					center = t.syms[symEW]
+				}
+			}
+		}
+
+		if center != SPACE && i < end && !t.columnSeparator(i) {
+			center = t.syms[symEW]
+		}
+
+		// Change Center start position
+		if center == SPACE {
+			if i < end && len(t.footers[i+1][0]) != 0 {
+				if !t.borders.Left {
+					center = t.syms[symEW]
+				} else {
+					center = t.syms[symNEW]
+				}
+			}
+		}
+
+		// Print the footer
+		line += strings.Repeat(pad, v+2) + center
+	}
+
+	fmt.Fprint(t.out, t.border(line), t.newLine)
+}
+
+// printFootnotes prints the numbered notes wrapped to the table width, with
+// continuation lines indented past the marker.
+func (t *Table) printFootnotes() {
+	width := t.renderedWidth()
+	for i, note := range t.footnotes {
+		marker := fmt.Sprintf("[%d] ", i+1)
+		indent := strings.Repeat(SPACE, DisplayWidth(marker))
+		lines, _ := WrapString(note, width-len(indent))
+		for n, line := range lines {
+			fmt.Fprint(t.out, ConditionString(n == 0, marker, indent), line, t.newLine)
+		}
+	}
+}
+
+// Print caption text
+func (t *Table) printCaption() {
+	width := t.getTableWidth()
+	paragraph, _ := WrapString(t.captionText, width)
+	for linecount := 0; linecount < len(paragraph); linecount++ {
+		line := paragraph[linecount]
+		switch t.captionAlign {
+		case ALIGN_CENTER:
+			line = strings.TrimRight(Pad(line, SPACE, t.renderedWidth()), SPACE)
+		case ALIGN_RIGHT:
+			line = PadLeft(line, SPACE, t.renderedWidth())
+		}
+		fmt.Fprint(t.out, line, t.newLine)
+	}
+}
+
+// renderedWidth returns the width of the table lines including borders.
+func (t *Table) renderedWidth() int {
+	width := 1
+	for i := 0; i < len(t.cs); i++ {
+		width += t.cs[i] + 3
+	}
+	return width
+}
+
+// Calculate the total number of characters in a row
+func (t *Table) getTableWidth() int {
+	var chars int
+	for _, v := range t.cs {
+		chars += v
+	}
+
+	// Add chars, spaces, seperators to calculate the total width of the table.
+	// ncols := t.colSize
+	// spaces := ncols * 2
+	// seps := ncols + 1
+
+	return (chars + (3 * t.colSize) + 2)
+}
+
+// applyConditionalWidths raises the columns to the width of the conditional
+// rules matching their cells.
+func (t *Table) applyConditionalWidths() {
+	if len(t.condMinWidths) == 0 {
+		return
+	}
+	for _, row := range t.rows {
+		t.widenConditional(row)
+	}
+}
+
+// widenConditional raises the columns to the width of the conditional rules
+// matching the cells of row.
+func (t *Table) widenConditional(row []string) {
+	for col, rules := range t.condMinWidths {
+		if col < 0 || col >= len(row) {
+			continue
+		}
+		for _, c := range rules {
+			if c.width > t.cs[col] && c.matcher.MatchString(row[col]) {
+				t.cs[col] = c.width
+			}
+		}
+	}
+}
+
+// applyFixedWidths sets the columns with a fixed width to that width,
+// re-wrapping their cells when the content is wider.
+func (t *Table) applyFixedWidths() {
+	if len(t.fixedWidths) == 0 {
+		return
+	}
+	n := len(t.cs)
+	for col, width := range t.fixedWidths {
+		if col < 0 || col >= n {
+			// Not a column of the table.
+			continue
+		}
+		if t.cs[col] != width {
+			t.cs[col] = width
+			t.rewrapColumn(col, width)
+		}
+	}
+	t.updateHeights()
+}
+
+// maxTableWidth returns the width the table has to fit in, or 0 if any.
+func (t *Table) maxTableWidth() int {
+	if t.autoTermWidth {
+		if w := terminalWidth(t.terminal()); w > 0 {
+			return w
+		}
+	}
+	return t.maxWidth
+}
+
+// splitChunks returns the columns of each table a wide table is split into.
+func (t *Table) splitChunks() [][]int {
+	maxWidth := t.maxTableWidth()
+	if !t.splitWide || maxWidth <= 0 {
+		return nil
+	}
+	key := t.splitKey
+	if key >= len(t.cs) {
+		key = -1
+	}
+	start, width := []int(nil), 1
+	if key >= 0 {
+		start, width = []int{key}, 1+t.cs[key]+3
+	}
+
+	var chunks [][]int
+	chunk, w := start, width
+	for i := 0; i < len(t.cs); i++ {
+		if i == key {
+			continue
+		}
+		if len(chunk) > len(start) && w+t.cs[i]+3 > maxWidth {
+			chunks = append(chunks, chunk)
+			chunk, w = start, width
+		}
+		chunk = append(append([]int(nil), chunk...), i)
+		w += t.cs[i] + 3
+	}
+	if len(chunk) > len(start) || len(chunks) == 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// SetVisibleColumns Render only the columns with the given headers
+// Columns are rendered in the order of names; names matching no header are
+// ignored. Without names every column is rendered.
+func (t *Table) SetVisibleColumns(names ...string) {
+	t.visibleCols = names
+}
+
+// SetHiddenColumns Leave columns out when rendering
+func (t *Table) SetHiddenColumns(cols ...int) {
+	t.hiddenCols = make(map[int]bool)
+	for _, c := range cols {
+		t.hiddenCols[c] = true
+	}
+}
+
+// shownColumns returns the columns to render when some are left out by
+// SetVisibleColumns or SetHiddenColumns, or nil to render all of them.
+func (t *Table) shownColumns() []int {
+	if len(t.visibleCols) == 0 && len(t.hiddenCols) == 0 {
+		return nil
+	}
+	var cols []int
+	if len(t.visibleCols) > 0 {
+		for _, name := range t.visibleCols {
+			if y := t.headerIndex(name); y >= 0 && !t.hiddenCols[y] {
+				cols = append(cols, y)
+			}
+		}
+	} else {
+		for y := 0; y < len(t.cs); y++ {
+			if !t.hiddenCols[y] {
+				cols = append(cols, y)
+			}
+		}
+	}
+	return cols
+}
+
+// SetTranspose Swap rows and columns when rendering
+// Each column is printed as a row starting with its header, which suits
+// single records with many fields. Settings of columns and rows, such as
+// column alignments, widths, colors, sorting and grouping, do not apply to
+// the transposed table.
+func (t *Table) SetTranspose(transpose bool) {
+	t.transpose = transpose
+}
+
+// SetRightToLeft Lay the table out from right to left
+// Columns are printed in reverse order and alignments are mirrored, cells
+// of the default alignment being aligned to the right, as suits Arabic or
+// Hebrew text. Bidirectional marks in cells take no width.
+func (t *Table) SetRightToLeft(rtl bool) {
+	t.rtl = rtl
+}
+
+// mirrored returns a copy of the table with its columns in reverse order
+// and its alignments mirrored.
+func (t *Table) mirrored() *Table {
+	cols := make([]int, len(t.cs))
+	for i := range cols {
+		cols[i] = len(cols) - 1 - i
+	}
+	p := t.project(cols)
+	p.rtl = false
+	mirror := func(align int) int {
+		switch align {
+		case ALIGN_LEFT:
+			return ALIGN_RIGHT
+		case ALIGN_RIGHT:
+			return ALIGN_LEFT
+		}
+		return align
+	}
+	for y, align := range p.columnsAlign {
+		if align == ALIGN_DEFAULT {
+			align = ALIGN_LEFT
+		}
+		p.columnsAlign[y] = mirror(align)
+	}
+	for i, cells := range p.cellStyles {
+		for y := range cells {
+			cells[y].Align = mirror(cells[y].Align)
+		}
+		p.cellStyles[i] = cells
+	}
+	p.hAlign, p.fAlign = mirror(t.hAlign), mirror(t.fAlign)
+	return p
+}
+
+// transposed returns a copy of the table whose rows are the columns of t,
+// headed by the header and ending with the footer of each column.
+func (t *Table) transposed() *Table {
+	p := t.derived()
+	// The cells were cleaned when appended to t.
+	p.sanitizer = nil
+
+	rows := t.pagedRows()
+	for y := 0; y < len(t.cs); y++ {
+		var row []string
+		if len(t.rawHeaders) > 0 {
+			row = append(row, t.cellOf(t.formatKeys(t.rawHeaders), y))
+		}
+		for _, i := range rows {
+			row = append(row, t.cellOf(t.rows[i], y))
+		}
+		if len(t.rawFooters) > 0 {
+			row = append(row, t.cellOf(t.formatKeys(t.rawFooters), y))
+		}
+		p.append(row)
+	}
+	return p
+}
+
+// cellOf returns cell y of row, or an empty string for a short row.
+func (t *Table) cellOf(row []string, y int) string {
+	if y < len(row) {
+		return row[y]
+	}
+	return ""
+}
+
+// project returns a copy of the table holding only the given columns, in the
+// given order. Cell contents are shared with t.
+func (t *Table) project(cols []int) *Table {
+	p := t.derived()
+	p.transpose = t.transpose
+	p.splitWide = t.splitWide
+	p.totalFooter = t.totalFooter
+	p.treeDepths, p.separators, p.dividers = t.treeDepths, t.separators, t.dividers
+	p.pageStart, p.pageSize = t.pageStart, t.pageSize
+	pick := func(src []string) []string {
+		var out []string
+		for _, c := range cols {
+			if c < len(src) {
+				out = append(out, src[c])
+			}
+		}
+		return out
+	}
+	pickLines := func(src [][]string) [][]string {
+		var out [][]string
+		for _, c := range cols {
+			if c < len(src) {
+				out = append(out, src[c])
+			}
+		}
+		return out
+	}
+	remap := func(src map[int]int) map[int]int {
+		out := make(map[int]int)
+		for i, c := range cols {
+			if v, ok := src[c]; ok {
+				out[i] = v
+			}
+		}
+		return out
+	}
+
+	p.cs = remap(t.cs)
+	p.minWidths = remap(t.minWidths)
+	p.fixedWidths = remap(t.fixedWidths)
+	p.shrinkPriority = remap(t.shrinkPriority)
+	if t.columnsToAutoMergeCells != nil {
+		p.columnsToAutoMergeCells = make(map[int]bool)
+	}
+	p.noMergeColumns = make(map[int]bool)
+	p.keepSpaceColumns = make(map[int]bool)
+	for cell := range t.nestedCells {
+		for i, c := range cols {
+			if c == cell[1] {
+				p.nestedCells[[2]int{cell[0], i}] = true
+			}
+		}
+	}
+	// Rows keep their order in every chunk, even if sorted by columns left
+	// out of it.
+	p.rowPerm = t.visibleRows()
+	for i, c := range cols {
+		if c == t.groupBy {
+			p.groupBy = i
+		}
+		if c == t.treeCol {
+			p.treeCol = i
+		}
+		if c == t.splitKey {
+			p.splitKey = i
+		}
+
+		for _, s := range t.subtotalCols {
+			if s == c {
+				p.subtotalCols = append(p.subtotalCols, i)
+			}
+		}
+		if equal := t.colMergeCompare[c]; equal != nil {
+			p.colMergeCompare[i] = equal
+		}
+		p.condMinWidths[i] = t.condMinWidths[c]
+		if t.columnsToAutoMergeCells[c] {
+			p.columnsToAutoMergeCells[i] = true
+		}
+		if t.noMergeColumns[c] {
+			p.noMergeColumns[i] = true
+		}
+		if t.keepSpaceColumns[c] {
+			p.keepSpaceColumns[i] = true
+		}
+		if ellipsis, ok := t.colEllipsis[c]; ok {
+			p.colEllipsis[i] = ellipsis
+		}
+		if colors, ok := t.heatMaps[c]; ok {
+			p.heatMaps[i] = colors
+		}
+		// A hidden separator stays hidden between the same two columns.
+		if i+1 < len(cols) {
+			next := cols[i+1]
+			p.noSeparators[i] = next == c+1 && t.noSeparators[c] || next == c-1 && t.noSeparators[next]
+		}
+	}
+
+	p.headers = pickLines(t.headers)
+	p.footers = pickLines(t.footers)
+	p.rawHeaders = pick(t.rawHeaders)
+	p.rawFooters = pick(t.rawFooters)
+	p.headerParams = pick(t.headerParams)
+	p.columnsParams = pick(t.columnsParams)
+	p.footerParams = pick(t.footerParams)
+	t.fillAlignment(len(t.cs))
+	p.columnsAlign = nil
+	for _, c := range cols {
+		if c < len(t.columnsAlign) {
+			p.columnsAlign = append(p.columnsAlign, t.columnsAlign[c])
+		}
+	}
+
+	p.lines = make([][][]string, len(t.lines))
+	p.rows = make([][]string, len(t.rows))
+	for i := range t.lines {
+		if cells := t.cellStyles[i]; cells != nil {
+			for _, c := range cols {
+				var cell Cell
+				if c < len(cells) {
+					cell = cells[c]
+				}
+				p.cellStyles[i] = append(p.cellStyles[i], cell)
+			}
+		}
+		p.lines[i] = pickLines(t.lines[i])
+		p.rows[i] = pick(t.rows[i])
+		for _, c := range cols {
+			if colors := t.rowColors[i]; c < len(colors) {
+				p.rowColors[i] = append(p.rowColors[i], colors[c])
+			} else if colors != nil {
+				p.rowColors[i] = append(p.rowColors[i], nil)
+			}
+		}
+	}
+	if len(t.headerGroups) > 0 {
+		// Columns of the same group that stay next to each other keep
+		// sharing a group cell.
+		var group []int
+		for n, g := range t.groupCells() {
+			for i := 0; i < g.Span; i++ {
+				group = append(group, n)
+			}
+		}
+		cells := t.groupCells()
+		for i, c := range cols {
+			if i > 0 && group[c] == group[cols[i-1]] {
+				p.headerGroups[len(p.headerGroups)-1].Span++
+				continue
+			}
+			p.headerGroups = append(p.headerGroups, HeaderGroup{Title: cells[group[c]].Title, Span: 1})
+		}
+	}
+	p.colSize = len(cols)
+	p.updateHeights()
+	return p
+}
+
+// fitWidth shrinks the columns so that the table is no wider than the
+// maximum width, re-wrapping the cells of every column that shrinks.
+func (t *Table) fitWidth() {
+	maxWidth := t.maxTableWidth()
+	n := len(t.cs)
+	if maxWidth <= 0 || n == 0 {
+		return
+	}
+
+	budget := maxWidth - t.frameWidth()
+	widths := make([]int, n)
+	mins := make([]int, n)
+	total := 0
+	for i := range widths {
+		widths[i] = t.cs[i]
+		mins[i] = t.minWidths[i]
+		if w, ok := t.fixedWidths[i]; ok {
+			mins[i] = w
+		}
+		total += widths[i]
+	}
+	if total <= budget {
+		return
+	}
+	for i, w := range t.shrinkByPriority(widths, mins, total-budget) {
+		if w < t.cs[i] {
+			t.cs[i] = w
+			t.rewrapColumn(i, w)
+		}
+	}
+	t.updateHeights()
+}
+
+// frameWidth returns the width of a printed line taken by separators and
+// padding rather than cell content. Each column takes two spaces and a
+// separator besides its content, or the table padding alone when there is
+// no white space. Disabled borders are printed as spaces, so they count.
+func (t *Table) frameWidth() int {
+	if t.noWhiteSpace {
+		return len(t.cs) * DisplayWidth(t.tablePadding)
+	}
+	return 3*len(t.cs) + 1
+}
+
+// fitted fits the columns for a render, returning a function restoring the
+// widths and lines as appended, so that a later render to a wider output
+// widens them again.
+func (t *Table) fitted() func() {
+	if t.maxTableWidth() <= 0 {
+		return func() {}
+	}
+	cs, rs := copyIntMap(t.cs), copyIntMap(t.rs)
+	headers := append([][]string{}, t.headers...)
+	footers := append([][]string{}, t.footers...)
+	lines := make([][][]string, len(t.lines))
+	for i, line := range t.lines {
+		lines[i] = append([][]string(nil), line...)
+	}
+	t.fitWidth()
+	return func() {
+		t.cs, t.rs = cs, rs
+		t.headers, t.footers = headers, footers
+		copy(t.lines, lines)
+	}
+}
+
+// shrinkByPriority removes excess cells from widths, taking them from the
+// columns with the highest shrink priority first.
+func (t *Table) shrinkByPriority(widths, mins []int, excess int) []int {
+	var levels []int
+	seen := make(map[int]bool)
+	for i := range widths {
+		if p := t.shrinkPriority[i]; !seen[p] {
+			seen[p] = true
+			levels = append(levels, p)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+
+	out := append([]int(nil), widths...)
+	for _, p := range levels {
+		var cols, ws, ms []int
+		total, spare := 0, 0
+		for i := range widths {
+			if t.shrinkPriority[i] != p {
+				continue
+			}
+			floor := mins[i]
+			if floor < 1 {
+				floor = 1
+			}
+			cols = append(cols, i)
+			ws = append(ws, widths[i])
+			ms = append(ms, floor)
+			total += widths[i]
+			if widths[i] > floor {
+				spare += widths[i] - floor
+			}
+		}
+		take := excess
+		if take > spare {
+			take = spare
+		}
+		for j, w := range shrinkWidths(ws, ms, total-take) {
+			out[cols[j]] = w
+		}
+		if excess -= take; excess <= 0 {
+			break
+		}
+	}
+	return out
+}
+
+// shrinkWidths reduces widths so that they add up to budget. Columns
+// narrower than an even share of the budget keep their width, columns are
+// not shrunk below mins and the remaining columns share what is left equally.
+func shrinkWidths(widths, mins []int, budget int) []int {
+	out := make([]int, len(widths))
+	fixed := make([]bool, len(widths))
+	left, open := budget, len(widths)
+	for changed := true; changed && open > 0; {
+		changed = false
+		share := left / open
+		for i, w := range widths {
+			if fixed[i] {
+				continue
+			}
+			switch {
+			case w <= share:
+				out[i] = w
+			case mins[i] >= share:
+				out[i] = mins[i]
+			default:
+				continue
+			}
+			fixed[i] = true
+			left -= out[i]
+			open--
+			changed = true
+		}
+	}
+	if open == 0 {
+		return out
+	}
+	// Cells lost to rounding go to the leftmost columns.
+	share, extra := left/open, left%open
+	for i := range widths {
+		if fixed[i] {
+			continue
+		}
+		out[i] = share
+		if extra > 0 {
+			out[i]++
+			extra--
+		}
+		if out[i] < 1 {
+			out[i] = 1
+		}
+	}
+	return out
+}
+
+// rewrapColumn wraps the cells of column col to width.
+func (t *Table) rewrapColumn(col, width int) {
+	rewrap := func(raw []string) []string {
+		v := " "
+		if col < len(raw) {
+			v = raw[col]
+		}
+		lines, _ := t.wrapCell(v, col, width, true)
+		return lines
+	}
+	if col < len(t.headers) {
+		t.headers[col] = t.limitLines(rewrap(t.rawHeaders), t.hMaxLines, width)
+	}
+	if col < len(t.footers) {
+		t.footers[col] = t.limitLines(rewrap(t.rawFooters), t.fMaxLines, width)
+	}
+	for i, line := range t.lines {
+		if col >= len(line) {
+			continue
+		}
+		if t.nestedCells[[2]int{i, col}] {
+			continue
+		}
+		if col == t.treeCol && t.treeDepths[i] > 0 {
+			// Leave room for the tree lines.
+			w := width - t.treeIndent(i)
+			if w < 1 {
+				w = 1
+			}
+			lines, _ := t.wrapCell(t.rows[i][col], col, w, true)
+			line[col] = t.limitLines(lines, t.maxLines, w)
+			continue
+		}
+		line[col] = t.limitLines(rewrap(t.rows[i]), t.maxLines, width)
+	}
+}
+
+// maxLinesFor returns the maximum number of lines of a cell in row rowKey.
+func (t *Table) maxLinesFor(rowKey int) int {
+	switch rowKey {
+	case headerRowIdx:
+		return t.hMaxLines
+	case footerRowIdx:
+		return t.fMaxLines
+	}
+	return t.maxLines
+}
+
+// limitLines cuts lines down to limit lines, ending the last one with the
+// line ellipsis. When width is positive the last line is kept within it.
+func (t *Table) limitLines(lines []string, limit, width int) []string {
+	if limit <= 0 || len(lines) <= limit {
+		return lines
+	}
+	lines = lines[:limit]
+	last := lines[limit-1]
+	if room := width - DisplayWidth(t.lineEllipsis); width > 0 && DisplayWidth(last) > room {
+		last = cutWidth(last, room)
+	}
+	lines[limit-1] = last + t.lineEllipsis
+	return lines
+}
+
+// updateHeights recomputes the number of lines of the header, the footer and
+// every row.
+func (t *Table) updateHeights() {
+	height := func(columns [][]string) int {
+		h := 0
+		for _, lines := range columns {
+			if len(lines) > h {
+				h = len(lines)
 			}
 		}
-		// Next line
-		fmt.Fprint(t.out, t.newLine)
+		return h
 	}
-	if t.hdrLine {
-		t.printLine(false, false)
+	t.rs[headerRowIdx] = height(t.headers)
+	t.rs[footerRowIdx] = height(t.footers)
+	for i, line := range t.lines {
+		t.rs[i] = height(line)
 	}
 }
 
-// Print heading information
-func (t *Table) printFooter() {
-	// Check if headers is available
-	if len(t.footers) < 1 {
-		return
+// printRows - print all the rows
+func (t *Table) printRows() {
+	order, more := t.rowOrder()
+	for k, i := range order {
+		t.rowPos = k
+		t.printDividers(i, k == 0 || t.rowLine || t.separators[order[k-1]])
+		t.printRow(t.lines[i], i)
+		last := k == len(order)-1 && more == 0
+		if t.rowLine && k == len(order)-1 && more > 0 {
+			// No column continues into the summary row.
+			t.printBoundaryLine(t.columnSeparator, never, false)
+		} else if t.rowLine {
+			t.printRowLine(last)
+		} else if t.separators[i] && !last {
+			t.printRowLine(false)
+		}
+	}
+	if more > 0 {
+		t.printMoreRows(more)
+		if t.rowLine {
+			t.printRowLine(true)
+		}
 	}
+}
 
-	// Only print line if border is not set
-	if !t.borders.Bottom {
-		t.printLine(false, false)
+// rowOrder returns the indexes of the rows to print, in order, and the
+// number of rows left out by SetMaxRows.
+func (t *Table) rowOrder() ([]int, int) {
+	order := t.pagedRows()
+	if t.maxRows > 0 && len(order) > t.maxRows {
+		return order[:t.maxRows], len(order) - t.maxRows
 	}
+	return order, 0
+}
 
-	// Identify last column
-	end := len(t.cs) - 1
+// SortKey is a column to sort rows by. Less compares two cells of the
+// column; by default numbers are compared by value and other cells as
+// strings.
+type SortKey struct {
+	Column int
+	Desc   bool
+	Less   func(a, b string) bool
+}
 
-	// Get pad function
-	padFunc := pad(t.fAlign)
+// SortBy Sort rows by a column when rendering
+func (t *Table) SortBy(column int, desc bool) {
+	t.SortByKeys(SortKey{Column: column, Desc: desc})
+}
 
-	// Checking for ANSI escape sequences for header
-	is_esc_seq := false
-	if len(t.footerParams) > 0 {
-		is_esc_seq = true
-	}
+// SortByKeys Sort rows by several columns when rendering
+// Rows equal by the first key are sorted by the next one, and rows equal
+// by all keys keep the order they were appended in.
+func (t *Table) SortByKeys(keys ...SortKey) {
+	t.sortKeys = keys
+}
 
-	// Maximum height.
-	max := t.rs[footerRowIdx]
+// SetRowFilter Set a function choosing the rows to render
+// Rows for which keep returns false are left out when rendering; cells are
+// the row as appended and idx its index. A nil keep renders every row.
+// Column widths still account for the rows left out.
+func (t *Table) SetRowFilter(keep func(cells []string, idx int) bool) {
+	t.rowFilter = keep
+}
 
-	// Print Footer
-	for i := 0; i < (len(t.cs) - len(t.footers)); i++ {
-		lines := t.parseDimension(" ", len(t.footers), footerRowIdx)
-		t.footers = append(t.footers, lines)
+// keep tells whether the row filter keeps row cells of index idx.
+func (t *Table) keep(cells []string, idx int) bool {
+	return t.rowFilter == nil || t.rowFilter(cells, idx)
+}
+
+// pagedRows returns the indexes of the visible rows of the page being
+// rendered by RenderPage, or of all visible rows.
+func (t *Table) pagedRows() []int {
+	order := t.visibleRows()
+	if t.pageSize == 0 {
+		return order
 	}
-	erasePad := make([]bool, len(t.footers))
-	for x := 0; x < max; x++ {
-		// Check if border is set
-		// Replace with space if not set
-		fmt.Fprint(t.out, ConditionString(t.borders.Bottom, t.syms[symNS], SPACE))
+	if t.pageStart >= len(order) {
+		return nil
+	}
+	end := t.pageStart + t.pageSize
+	if end > len(order) {
+		end = len(order)
+	}
+	return order[t.pageStart:end]
+}
 
-		for y := 0; y <= end; y++ {
-			v := t.cs[y]
-			f := ""
-			if y < len(t.footers) && x < len(t.footers[y]) {
-				f = t.footers[y][x]
+// visibleRows returns the indexes of the rows kept by the row filter,
+// sorted by the sort keys.
+func (t *Table) visibleRows() []int {
+	if t.rowPerm != nil {
+		return t.rowPerm
+	}
+	order := make([]int, 0, len(t.lines))
+	for i := range t.lines {
+		if t.keep(t.rows[i], i) {
+			order = append(order, i)
+		}
+	}
+	if len(t.sortKeys) == 0 {
+		return order
+	}
+	cell := func(i, y int) string {
+		if y < len(t.rows[i]) {
+			return t.rows[i][y]
+		}
+		return ""
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		for _, key := range t.sortKeys {
+			less := key.Less
+			if less == nil {
+				less = lessCell
 			}
-			if t.autoFmt {
-				f = Title(f)
+			x, y := cell(order[a], key.Column), cell(order[b], key.Column)
+			if key.Desc {
+				x, y = y, x
 			}
-			pad := ConditionString((y == end && !t.borders.Top), SPACE, t.syms[symNS])
-
-			if erasePad[y] || (x == 0 && len(f) == 0) {
-				pad = SPACE
-				erasePad[y] = true
+			if less(x, y) {
+				return true
 			}
-
-			if is_esc_seq {
-				fmt.Fprintf(t.out, " %s %s",
-					format(padFunc(f, SPACE, v),
-						t.footerParams[y]), pad)
-			} else {
-				fmt.Fprintf(t.out, " %s %s",
-					padFunc(f, SPACE, v),
-					pad)
+			if less(y, x) {
+				return false
 			}
-
-			//fmt.Fprintf(t.out, " %s %s",
-			//	padFunc(f, SPACE, v),
-			//	pad)
 		}
-		// Next line
-		fmt.Fprint(t.out, t.newLine)
+		return false
+	})
+	return order
+}
+
+// lessCell compares cells as numbers if both are numbers, else as strings.
+func lessCell(a, b string) bool {
+	x, y := strings.TrimSpace(a), strings.TrimSpace(b)
+	if decimal.MatchString(x) && decimal.MatchString(y) {
+		m, _ := strconv.ParseFloat(strings.Replace(x, ",", "", -1), 64)
+		n, _ := strconv.ParseFloat(strings.Replace(y, ",", "", -1), 64)
+		return m < n
 	}
+	return a < b
+}
 
-	hasPrinted := false
+// printMoreRows prints the summary row for rows left out by SetMaxRows.
+func (t *Table) printMoreRows(more int) {
+	text := fmt.Sprintf("... and %d more rows", more)
+	if more == 1 {
+		text = "... and 1 more row"
+	}
+	t.printSpanningRow(text, ALIGN_LEFT, "", SPACE)
+}
 
-	for i := 0; i <= end; i++ {
-		v := t.cs[i]
-		pad := t.syms[symEW]
-		center := t.syms[symNEW]
-		length := len(t.footers[i][0])
+// printTitle prints the title band with the lines above and below it.
+func (t *Table) printTitle() {
+	if t.borders.Top {
+		t.printBoundaryLine(never, never, true)
+	}
+	t.printSpanningRow(t.title, t.titleAlign, t.titleParams, SPACE)
+	t.printBoundaryLine(never, t.columnBoundary, false)
+}
 
-		if length > 0 {
-			hasPrinted = true
-		}
+// never reports no column separator at any boundary.
+func never(int) bool { return false }
 
-		// Set center to be space if length is 0
-		if length == 0 && !t.borders.Right {
-			center = SPACE
-		}
+// columnBoundary reports whether a column separator follows column i in the
+// first row of cells below the title, which are the header groups if set.
+func (t *Table) columnBoundary(i int) bool {
+	if len(t.headerGroups) == 0 || len(t.headers) == 0 {
+		return t.columnSeparator(i)
+	}
+	return t.groupBoundary(i)
+}
 
-		// Print first junction
-		if i == 0 {
-			if length > 0 && !t.borders.Left {
-				center = t.syms[symEW]
-			} else if center != SPACE {
-				center = t.syms[symNE]
-			}
-			fmt.Fprint(t.out, center)
+// SetColumnSeparatorAfter Show or hide the separator between a column and
+// the next one
+// Hiding it joins paired columns, such as an icon and its label, into one
+// unit. Separators are shown by default.
+func (t *Table) SetColumnSeparatorAfter(column int, show bool) {
+	t.noSeparators[column] = !show
+}
+
+// columnSeparator reports whether a column separator follows column i in
+// the cells of the table.
+func (t *Table) columnSeparator(i int) bool {
+	return !t.noSeparators[i]
+}
+
+// groupBoundary reports whether a header group ends with column i.
+func (t *Table) groupBoundary(i int) bool {
+	end := 0
+	for _, g := range t.groupCells() {
+		end += g.Span
+		if end-1 >= i {
+			return end-1 == i
 		}
+	}
+	return true
+}
 
-		// Pad With space of length is 0
-		if length == 0 {
-			pad = SPACE
+// groupCells returns the header groups clipped to the table columns, with
+// an untitled group for every column past the last group.
+func (t *Table) groupCells() []HeaderGroup {
+	var cells []HeaderGroup
+	col := 0
+	for _, g := range t.headerGroups {
+		if g.Span < 1 || col >= len(t.cs) {
+			continue
 		}
-		// Ignore left space as it has printed before
-		if hasPrinted || t.borders.Left {
-			pad = t.syms[symEW]
-			center = t.syms[symNEW]
+		if col+g.Span > len(t.cs) {
+			g.Span = len(t.cs) - col
 		}
+		cells = append(cells, g)
+		col += g.Span
+	}
+	for ; col < len(t.cs); col++ {
+		cells = append(cells, HeaderGroup{Span: 1})
+	}
+	return cells
+}
 
-		// Change Center end position
-		if center != SPACE {
-			if i == end {
-				if t.borders.Right {
-					center = t.syms[symNW]
-				} else {
-					center = t.syms[symEW]
-				}
-			}
+// printBoundaryLine prints a horizontal line whose junctions connect to the
+// column separators above and below it, as reported by up and down for the
+// boundary following each column.
+func (t *Table) printBoundaryLine(up, down func(int) bool, isFirst bool) {
+	left := ConditionString(isFirst, t.syms[symES], t.syms[symNES])
+	line := ConditionString(t.borders.Left, left, t.syms[symEW])
+	for i := 0; i < len(t.cs); i++ {
+		junction := t.syms[symEW]
+		switch {
+		case i == len(t.cs)-1:
+			right := ConditionString(isFirst, t.syms[symSW], t.syms[symNSW])
+			junction = ConditionString(t.borders.Right, right, t.syms[symEW])
+		case up(i) && down(i):
+			junction = t.syms[symNESW]
+		case up(i):
+			junction = t.syms[symNEW]
+		case down(i):
+			junction = t.syms[symESW]
 		}
+		line += strings.Repeat(t.syms[symEW], t.cs[i]+2) + junction
+	}
+	fmt.Fprint(t.out, t.border(line), t.newLine)
+}
 
-		// Change Center start position
-		if center == SPACE {
-			if i < end && len(t.footers[i+1][0]) != 0 {
-				if !t.borders.Left {
-					center = t.syms[symEW]
-				} else {
-					center = t.syms[symNEW]
-				}
-			}
+// fitHeaderGroups widens the last column of every header group whose title
+// doesn't fit the columns it spans.
+func (t *Table) fitHeaderGroups() {
+	if len(t.headerGroups) == 0 {
+		return
+	}
+	col := 0
+	for _, g := range t.groupCells() {
+		if w, width := DisplayWidth(t.groupTitle(g)), t.spanWidth(col, g.Span); w > width {
+			t.cs[col+g.Span-1] += w - width
 		}
+		col += g.Span
+	}
+}
 
-		// Print the footer
-		fmt.Fprintf(t.out, "%s%s%s%s",
-			pad,
-			strings.Repeat(string(pad), v),
-			pad,
-			center)
+// spanWidth returns the width of a cell spanning span columns from col.
+func (t *Table) spanWidth(col, span int) int {
+	width := -3
+	for i := col; i < col+span; i++ {
+		width += t.cs[i] + 3
+	}
+	return width
+}
 
+// groupTitle returns the title of g as printed.
+func (t *Table) groupTitle(g HeaderGroup) string {
+	if t.autoFmt {
+		return Title(g.Title)
 	}
+	return g.Title
+}
 
+// printHeaderGroups prints the header groups and the line below them.
+func (t *Table) printHeaderGroups() {
+	if len(t.headerGroups) == 0 || len(t.headers) == 0 {
+		return
+	}
+	padFunc := pad(t.hAlign)
+	fmt.Fprint(t.out, ConditionString(t.borders.Left, t.border(t.syms[symNS]), SPACE))
+	col := 0
+	for _, g := range t.groupCells() {
+		width := t.spanWidth(col, g.Span)
+		col += g.Span
+		sep := ConditionString(col == len(t.cs) && !t.borders.Right, SPACE, t.border(t.syms[symNS]))
+		fmt.Fprintf(t.out, " %s %s", padFunc(t.groupTitle(g), SPACE, width), sep)
+	}
 	fmt.Fprint(t.out, t.newLine)
+	t.printBoundaryLine(t.groupBoundary, t.columnSeparator, false)
 }
 
-// Print caption text
-func (t *Table) printCaption() {
-	width := t.getTableWidth()
-	paragraph, _ := WrapString(t.captionText, width)
-	for linecount := 0; linecount < len(paragraph); linecount++ {
-		fmt.Fprintln(t.out, paragraph[linecount])
+// printSpanningRow prints text in a single cell spanning all columns,
+// formatted with the given color attributes.
+func (t *Table) printSpanningRow(text string, align int, params, fill string) {
+	width := t.spanningWidth()
+	if width < 0 {
+		return
+	}
+	lines, _ := WrapString(text, width)
+	lines = splitLines(lines, width)
+	padFunc := pad(align)
+	for _, line := range lines {
+		line = padFunc(line, fill, width)
+		if params != "" && t.colorEnabled() {
+			line = format(line, params)
+		}
+		t.printBandLine(line)
 	}
 }
 
-// Calculate the total number of characters in a row
-func (t *Table) getTableWidth() int {
-	var chars int
-	for _, v := range t.cs {
-		chars += v
+// printBandLine prints line, already as wide as a spanning cell, between
+// the borders of the table.
+func (t *Table) printBandLine(line string) {
+	if t.noWhiteSpace {
+		// Like the rows, end with the padding and leave out the borders.
+		fmt.Fprint(t.out, line, t.tablePadding, t.newLine)
+		return
 	}
-
-	// Add chars, spaces, seperators to calculate the total width of the table.
-	// ncols := t.colSize
-	// spaces := ncols * 2
-	// seps := ncols + 1
-
-	return (chars + (3 * t.colSize) + 2)
+	fmt.Fprint(t.out, t.border(ConditionString(t.borders.Left, t.syms[symNS], SPACE)))
+	fmt.Fprintf(t.out, " %s ", line)
+	fmt.Fprint(t.out, t.border(ConditionString(t.borders.Right, t.syms[symNS], SPACE)))
+	fmt.Fprint(t.out, t.newLine)
 }
 
-// printRows - print all the rows
-func (t *Table) printRows() {
-	for i, lines := range t.lines {
-		t.printRow(lines, i)
+// spanningWidth returns the width of a cell spanning all columns, which
+// takes the whole line but for the borders and padding.
+func (t *Table) spanningWidth() int {
+	width := t.frameWidth()
+	for i := 0; i < len(t.cs); i++ {
+		width += t.cs[i]
+	}
+	if t.noWhiteSpace {
+		return width - DisplayWidth(t.tablePadding)
 	}
+	return width - 4
 }
 
 // fillAlignment - fill the alignment
@@ -851,8 +3033,22 @@ func (t *Table) fillAlignment(num int) {
 // Print Row Information
 // Adjust column alignment based on type
 func (t *Table) printRow(columns [][]string, rowIdx int) {
+	columns = t.treeColumns(columns, rowIdx)
+	if rowIdx >= 0 {
+		t.notifyCells(SectionRow, rowIdx, columns)
+	}
+
 	// Get Maximum Height
-	max := t.rs[rowIdx]
+	max, ok := t.rs[rowIdx]
+	if !ok {
+		// Rows printed but not stored, such as subtotals, take their height
+		// from their lines.
+		for _, lines := range columns {
+			if len(lines) > max {
+				max = len(lines)
+			}
+		}
+	}
 	total := len(columns)
 
 	// TODO Fix uneven col size
@@ -863,55 +3059,56 @@ func (t *Table) printRow(columns [][]string, rowIdx int) {
 	//	}
 	//}
 
-	// Pad Each Height
-	pads := []int{}
-
 	// Checking for ANSI escape sequences for columns
 	is_esc_seq := false
-	if len(t.columnsParams) > 0 {
+	if len(t.columnsParams) > 0 && t.colorEnabled() {
 		is_esc_seq = true
 	}
 	t.fillAlignment(total)
 
 	for i, line := range columns {
-		length := len(line)
-		pad := max - length
-		pads = append(pads, pad)
+		pad := max - len(line)
 		for n := 0; n < pad; n++ {
-			columns[i] = append(columns[i], "  ")
+			columns[i] = append(columns[i], "")
 		}
 	}
-	//fmt.Println(max, "\n")
+	// Every line is built in a buffer and written at once.
+	w := bufPool.Get().(*bytes.Buffer)
+	defer bufPool.Put(w)
+	w.Reset()
 	for x := 0; x < max; x++ {
 		for y := 0; y < total; y++ {
 
 			// Check if border is set
 			if !t.noWhiteSpace {
-				fmt.Fprint(t.out, ConditionString((!t.borders.Left && y == 0), SPACE, t.syms[symNS]))
-				fmt.Fprintf(t.out, SPACE)
+				w.WriteString(ConditionString((!t.borders.Left && y == 0) || !t.columnSeparator(y-1), SPACE, t.border(t.syms[symNS])))
+				w.WriteString(SPACE)
 			}
 
-			str := columns[y][x]
+			str := t.rowColor(rowIdx, y, x, columns[y][x])
 
-			// Embedding escape sequence with column value
-			if is_esc_seq {
+			// Embedding escape sequence with cell or column value
+			if params := t.cellParams(rowIdx, y); params != "" {
+				str = format(str, params)
+			} else if is_esc_seq {
 				str = format(str, t.columnsParams[y])
 			}
 
 			// This would print alignment
 			// Default alignment  would use multiple configuration
-			switch t.columnsAlign[y] {
+			var cell string
+			switch t.cellAlign(rowIdx, y) {
 			case ALIGN_CENTER: //
-				fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
+				cell = Pad(str, SPACE, t.cs[y])
 			case ALIGN_RIGHT:
-				fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
+				cell = PadLeft(str, SPACE, t.cs[y])
 			case ALIGN_LEFT:
-				fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
+				cell = PadRight(str, SPACE, t.cs[y])
 			default:
 				if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-					fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
+					cell = PadLeft(str, SPACE, t.cs[y])
 				} else {
-					fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
+					cell = PadRight(str, SPACE, t.cs[y])
 
 					// TODO Custom alignment per column
 					//if max == 1 || pads[y] > 0 {
@@ -922,22 +3119,20 @@ func (t *Table) printRow(columns [][]string, rowIdx int) {
 
 				}
 			}
+			w.WriteString(t.drawCell(SectionRow, rowIdx, y, x, columns[y][x], t.stripe(cell, rowIdx)))
 			if !t.noWhiteSpace {
-				fmt.Fprintf(t.out, SPACE)
+				w.WriteString(SPACE)
 			} else {
-				fmt.Fprintf(t.out, t.tablePadding)
+				w.WriteString(t.tablePadding)
 			}
 		}
 		// Check if border is set
 		// Replace with space if not set
 		if !t.noWhiteSpace {
-			fmt.Fprint(t.out, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
+			w.WriteString(ConditionString(t.borders.Left, t.border(t.syms[symNS]), SPACE))
 		}
-		fmt.Fprint(t.out, t.newLine)
-	}
-
-	if t.rowLine {
-		t.printLine(false, rowIdx == len(t.lines)-1 && len(t.footers) == 0)
+		w.WriteString(t.newLine)
+		w.WriteTo(t.out)
 	}
 }
 
@@ -946,43 +3141,95 @@ func (t *Table) printRowsMergeCells() {
 	var previousLine []string
 	var displayCellBorder []bool
 	var tmpWriter bytes.Buffer
-	for i, lines := range t.lines {
+	order, more := t.rowOrder()
+	for k, i := range order {
+		t.rowPos = k
+		if len(t.dividers[i]) > 0 {
+			previousLine = nil
+		}
 		// We store the display of the current line in a tmp writer, as we need to know which border needs to be print above
-		previousLine, displayCellBorder = t.printRowMergeCells(&tmpWriter, lines, i, previousLine)
-		if i > 0 { //We don't need to print borders above first line
-			if t.rowLine {
-				t.printLineOptionalCellSeparators(true, displayCellBorder)
+		previousLine, displayCellBorder = t.printRowMergeCells(&tmpWriter, t.lines[i], i, previousLine)
+		lined := k == 0
+		if k > 0 { //We don't need to print borders above first line
+			if t.rowLine || t.separators[order[k-1]] {
+				t.sectionLine(SectionRow, func() { t.printLineOptionalCellSeparators(true, displayCellBorder) })
+				lined = true
 			}
 		}
+		t.printDividers(i, lined)
 		tmpWriter.WriteTo(t.out)
 	}
+	if more > 0 {
+		if t.rowLine {
+			t.printBoundaryLine(t.columnSeparator, never, false)
+		}
+		t.printMoreRows(more)
+	}
 	//Print the end of the table
 	if t.rowLine {
 		t.printLine(false, true)
 	}
 }
 
+// isMergeColumn reports whether identical cells of column y may be merged.
+func (t *Table) isMergeColumn(y int) bool {
+	if t.noMergeColumns[y] {
+		return false
+	}
+	if t.columnsToAutoMergeCells != nil {
+		// Check to see if the column index is in columnsToAutoMergeCells.
+		return t.columnsToAutoMergeCells[y]
+	}
+	// columnsToAutoMergeCells was not set.
+	return true
+}
+
+// mergeEqual reports whether cells a and b of column y are identical for
+// auto merge.
+func (t *Table) mergeEqual(y int, a, b string) bool {
+	if equal := t.colMergeCompare[y]; equal != nil {
+		return equal(a, b)
+	}
+	if t.mergeCompare != nil {
+		return t.mergeCompare(a, b)
+	}
+	return a == b
+}
+
+// mergeRuns returns, for each column of a row made of columns, the full text
+// of the cell starting the run of merged cells it belongs to, given those of
+// the row above in previous. Cells are always compared with the start of
+// their run, so a comparator only has to hold for each cell against the first.
+func (t *Table) mergeRuns(previous []string, columns [][]string) []string {
+	runs := make([]string, len(columns))
+	for y := range columns {
+		runs[y] = strings.TrimRight(strings.Join(columns[y], " "), " ")
+		if y < len(previous) && runs[y] != "" && previous[y] != "" && t.isMergeColumn(y) && t.mergeEqual(y, previous[y], runs[y]) {
+			runs[y] = previous[y]
+		}
+	}
+	return runs
+}
+
 // Print Row Information to a writer and merge identical cells.
 // Adjust column alignment based on type
 func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx int, previousLine []string) ([]string, []bool) {
+	columns = t.treeColumns(columns, rowIdx)
+	t.notifyCells(SectionRow, rowIdx, columns)
+
 	// Get Maximum Height
 	max := t.rs[rowIdx]
 	total := len(columns)
 
-	// Pad Each Height
-	pads := []int{}
-
 	// Checking for ANSI escape sequences for columns
 	isEscSeq := false
-	if len(t.columnsParams) > 0 {
+	if len(t.columnsParams) > 0 && t.colorEnabled() {
 		isEscSeq = true
 	}
 	for i, line := range columns {
-		length := len(line)
-		pad := max - length
-		pads = append(pads, pad)
+		pad := max - len(line)
 		for n := 0; n < pad; n++ {
-			columns[i] = append(columns[i], "  ")
+			columns[i] = append(columns[i], "")
 		}
 	}
 
@@ -992,34 +3239,27 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 		for y := 0; y < total; y++ {
 
 			// Check if border is set
-			fmt.Fprint(writer, ConditionString((!t.borders.Left && y == 0), SPACE, t.syms[symNS]))
+			fmt.Fprint(writer, ConditionString((!t.borders.Left && y == 0) || !t.columnSeparator(y-1), SPACE, t.border(t.syms[symNS])))
 
 			fmt.Fprintf(writer, SPACE)
 
-			str := columns[y][x]
+			str := t.rowColor(rowIdx, y, x, columns[y][x])
+			value := columns[y][x]
 
-			// Embedding escape sequence with column value
-			if isEscSeq {
+			// Embedding escape sequence with cell or column value
+			if params := t.cellParams(rowIdx, y); params != "" {
+				str = format(str, params)
+			} else if isEscSeq {
 				str = format(str, t.columnsParams[y])
 			}
 
 			if t.autoMergeCells {
-				var mergeCell bool
-				if t.columnsToAutoMergeCells != nil {
-					// Check to see if the column index is in columnsToAutoMergeCells.
-					if t.columnsToAutoMergeCells[y] {
-						mergeCell = true
-					}
-				} else {
-					// columnsToAutoMergeCells was not set.
-					mergeCell = true
-				}
 				//Store the full line to merge mutli-lines cells
 				fullLine := strings.TrimRight(strings.Join(columns[y], " "), " ")
-				if len(previousLine) > y && fullLine == previousLine[y] && fullLine != "" && mergeCell {
+				if len(previousLine) > y && fullLine != "" && previousLine[y] != "" && t.isMergeColumn(y) && t.mergeEqual(y, previousLine[y], fullLine) {
 					// If this cell is identical to the one above but not empty, we don't display the border and keep the cell empty.
 					displayCellBorder = append(displayCellBorder, false)
-					str = ""
+					str, value = "", ""
 				} else {
 					// First line or different content, keep the content and print the cell border
 					displayCellBorder = append(displayCellBorder, true)
@@ -1028,63 +3268,149 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 
 			// This would print alignment
 			// Default alignment  would use multiple configuration
-			switch t.columnsAlign[y] {
+			var cell string
+			switch t.cellAlign(rowIdx, y) {
 			case ALIGN_CENTER: //
-				fmt.Fprintf(writer, "%s", Pad(str, SPACE, t.cs[y]))
+				cell = Pad(str, SPACE, t.cs[y])
 			case ALIGN_RIGHT:
-				fmt.Fprintf(writer, "%s", PadLeft(str, SPACE, t.cs[y]))
+				cell = PadLeft(str, SPACE, t.cs[y])
 			case ALIGN_LEFT:
-				fmt.Fprintf(writer, "%s", PadRight(str, SPACE, t.cs[y]))
+				cell = PadRight(str, SPACE, t.cs[y])
 			default:
 				if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-					fmt.Fprintf(writer, "%s", PadLeft(str, SPACE, t.cs[y]))
+					cell = PadLeft(str, SPACE, t.cs[y])
 				} else {
-					fmt.Fprintf(writer, "%s", PadRight(str, SPACE, t.cs[y]))
+					cell = PadRight(str, SPACE, t.cs[y])
 				}
 			}
+			fmt.Fprint(writer, t.drawCell(SectionRow, rowIdx, y, x, value, t.stripe(cell, rowIdx)))
 			fmt.Fprintf(writer, SPACE)
 		}
 		// Check if border is set
 		// Replace with space if not set
-		fmt.Fprint(writer, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
+		fmt.Fprint(writer, ConditionString(t.borders.Left, t.border(t.syms[symNS]), SPACE))
 		fmt.Fprint(writer, t.newLine)
 	}
 
-	//The new previous line is the current one
-	previousLine = make([]string, total)
-	for y := 0; y < total; y++ {
-		previousLine[y] = strings.TrimRight(strings.Join(columns[y], " "), " ") //Store the full line for multi-lines cells
-	}
+	//The new previous line holds the cells starting the runs of this one
+	previousLine = t.mergeRuns(previousLine, columns)
 	//Returns the newly added line and wether or not a border should be displayed above.
 	return previousLine, displayCellBorder
 }
 
 // parseDimension - parse table dimensions
 func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
+	raw, maxWidth := t.measureCell(str, colKey, rowKey)
+	t.recordDimension(colKey, rowKey, maxWidth, len(raw))
+	return raw
+}
+
+// measureCell breaks cell str of column colKey in row rowKey into lines,
+// returning them with the width the cell needs. It changes no state, so
+// cells may be measured concurrently.
+func (t *Table) measureCell(str string, colKey, rowKey int) ([]string, int) {
+	raw, maxWidth := t.wrapCell(str, colKey, t.mW, false)
+	if rowKey == headerRowIdx && t.verticalHeader {
+		raw, maxWidth = nil, 0
+		for _, c := range graphemes(strings.Replace(str, "\n", "", -1)) {
+			raw = append(raw, c)
+			if w := DisplayWidth(c); w > maxWidth {
+				maxWidth = w
+			}
+		}
+	} else if t.nestedCells[[2]int{rowKey, colKey}] {
+		// Nested tables keep their lines as rendered.
+		raw, maxWidth = getLines(str), 0
+		for _, line := range raw {
+			if w := DisplayWidth(line); w > maxWidth {
+				maxWidth = w
+			}
+		}
+	} else if limit := t.maxLinesFor(rowKey); limit > 0 && len(raw) > limit {
+		width := 0
+		if t.autoWrap {
+			width = maxWidth
+		}
+		raw = t.limitLines(raw, limit, width)
+		for _, line := range raw {
+			if w := DisplayWidth(line); w > maxWidth {
+				maxWidth = w
+			}
+		}
+	}
+
+	return raw, maxWidth
+}
+
+// recordDimension records the width and height of a cell of column colKey
+// in row rowKey.
+func (t *Table) recordDimension(colKey, rowKey, maxWidth, h int) {
+	// Store the new known maximum width.
+	v, ok := t.cs[colKey]
+	if !ok || v < maxWidth || v == 0 {
+		t.cs[colKey] = maxWidth
+	}
+
+	// Remember the number of lines for the row printer.
+	v, ok = t.rs[rowKey]
+
+	if !ok || v < h || v == 0 {
+		t.rs[rowKey] = h
+	}
+}
+
+// wrapCell breaks str, a cell of column col, into lines no wider than limit
+// when wrapping is enabled and returns them along with the widest line. With
+// hard set the lines are guaranteed to fit: words longer than limit are
+// split, or lines are truncated when wrapping is disabled.
+func (t *Table) wrapCell(str string, col, limit int, hard bool) ([]string, int) {
 	var (
 		raw      []string
 		maxWidth int
 	)
 
+	keepSpace := t.keepSpaceColumns[col]
 	raw = getLines(str)
 	maxWidth = 0
-	for _, line := range raw {
+	for i, line := range raw {
+		if t.tabWidth > 0 && (!t.autoWrap || keepSpace) {
+			line = expandTabs(line, t.tabWidth)
+			raw[i] = line
+		}
 		if w := DisplayWidth(line); w > maxWidth {
 			maxWidth = w
 		}
 	}
 
+	if hard && !t.autoWrap {
+		for i, line := range raw {
+			raw[i] = truncate(line, limit, t.ellipsisFor(col), t.truncatePos)
+		}
+		if maxWidth > limit {
+			maxWidth = limit
+		}
+		return raw, maxWidth
+	}
+
+	if keepSpace {
+		if hard && maxWidth > limit {
+			raw = splitLines(raw, limit)
+			maxWidth = limit
+		}
+		return raw, maxWidth
+	}
+
 	// If wrapping, ensure that all paragraphs in the cell fit in the
 	// specified width.
-	if t.autoWrap {
+	if t.autoWrap || hard {
 		// If there's a maximum allowed width for wrapping, use that.
-		if maxWidth > t.mW {
-			maxWidth = t.mW
+		if maxWidth > limit {
+			maxWidth = limit
 		}
 
 		// In the process of doing so, we need to recompute maxWidth. This
 		// is because perhaps a word in the cell is longer than the
-		// allowed maximum width in t.mW.
+		// allowed maximum width in limit.
 		newMaxWidth := maxWidth
 		newRaw := make([]string, 0, len(raw))
 
@@ -1094,6 +3420,9 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
 		}
 		for i, para := range raw {
 			paraLines, _ := WrapString(para, maxWidth)
+			if hard {
+				paraLines = splitLines(paraLines, limit)
+			}
 			for _, line := range paraLines {
 				if w := DisplayWidth(line); w > newMaxWidth {
 					newMaxWidth = w
@@ -1107,20 +3436,5 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
 		raw = newRaw
 		maxWidth = newMaxWidth
 	}
-
-	// Store the new known maximum width.
-	v, ok := t.cs[colKey]
-	if !ok || v < maxWidth || v == 0 {
-		t.cs[colKey] = maxWidth
-	}
-
-	// Remember the number of lines for the row printer.
-	h := len(raw)
-	v, ok = t.rs[rowKey]
-
-	if !ok || v < h || v == 0 {
-		t.rs[rowKey] = h
-	}
-	//fmt.Printf("Raw %+v %d\n", raw, len(raw))
-	return raw
+	return raw, maxWidth
 }
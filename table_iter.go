@@ -0,0 +1,25 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package tablewriter
+
+import "iter"
+
+// AppendSeq Append every row yielded by seq
+func (t *Table) AppendSeq(seq iter.Seq[[]string]) {
+	for row := range seq {
+		t.Append(row)
+	}
+}
+
+// AppendSeq2 Append every row yielded by seq, ignoring its indexes
+// This accepts iterators such as slices.All directly.
+func (t *Table) AppendSeq2(seq iter.Seq2[int, []string]) {
+	for _, row := range seq {
+		t.Append(row)
+	}
+}
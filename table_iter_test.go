@@ -0,0 +1,34 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package tablewriter
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestAppendSeq(t *testing.T) {
+	rows := [][]string{{"A", "1"}, {"B", "2"}}
+	want := `+---+---+
+| A | 1 |
+| B | 2 |
++---+---+
+`
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.AppendSeq(slices.Values(rows))
+	table.Render()
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.AppendSeq2(slices.All(rows))
+	table.Render()
+	checkEqual(t, buf.String(), want)
+}
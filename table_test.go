@@ -8,13 +8,20 @@
 package tablewriter
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func checkEqual(t *testing.T, got, want interface{}, msgs ...interface{}) {
@@ -1688,3 +1695,2169 @@ func TestStructs(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderRow(t *testing.T) {
+	data := [][]string{
+		{"A", "The Good", "500"},
+		{"B", "The Very very Bad Man", "288"},
+		{"C", "The Ugly", "120"},
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.AppendBulk(data)
+	table.Render()
+
+	buf.Reset()
+	if err := table.RenderRow(&buf, 2); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, buf.String(), "| C    | The Ugly              |    120 |\n")
+
+	if err := table.RenderRow(&buf, 3); err == nil {
+		t.Error("expected error for out of range row")
+	}
+
+	// The row is laid out as Render prints it.
+	table.SetHiddenColumns(0)
+	table.SetMaxWidth(24)
+	buf.Reset()
+	if err := table.RenderRow(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+	want := "| The Very    |    288 |\n" +
+		"| very Bad    |        |\n" +
+		"| Man         |        |\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestConditionalColMinWidth(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+----+----------+
+| ID |  STATUS  |
++----+----------+
+|  1 | ok       |
+|  2 | FAIL     |
++----+----------+
+`
+	)
+	table.SetConditionalColMinWidth(0, regexp.MustCompile(`^x`), 8)
+	table.SetHeader([]string{"ID", "Status"})
+	table.Append([]string{"1", "ok"})
+	table.Append([]string{"2", "FAIL"})
+	// Rules also apply to the rows appended before them.
+	table.SetConditionalColMinWidth(1, regexp.MustCompile(`^FAIL`), 8)
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSanitizeRows(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+-----------+--------+
+|   NAME    | STATUS |
++-----------+--------+
+| evil name | ` + "\033[31mfailed\033[0m" + ` |
++-----------+--------+
+`
+	)
+	table.SetHeader([]string{"Name", "Status"})
+	table.Append([]string{"evil\033]0;pwned\a name\033[2J\x9b", "\033[31mfailed\033[0m"})
+	table.Render()
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetSanitizer(nil)
+	table.Append([]string{"\033[2J"})
+	table.Render()
+	if !strings.Contains(buf.String(), "\033[2J") {
+		t.Error("expected raw control sequence with sanitizer disabled")
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk([][]string{
+		{"A", "The Good & Bad", "500"},
+		{"A", "The Ugly", "1,200"},
+		{"B", "The Gopher", "800"},
+		{"C", "007", "12345678901234567890"},
+	})
+
+	var out bytes.Buffer
+	if err := table.WriteXLSX(&out); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sheet []byte
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sheet, _ = ioutil.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	for _, want := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">NAME</t></is></c>`,
+		`<t xml:space="preserve">The Good &amp; Bad</t>`,
+		`<c r="C3"><v>1200</v></c>`,
+		`<c r="B5" s="0" t="inlineStr"><is><t xml:space="preserve">007</t></is></c>`,
+		`<c r="C5" s="0" t="inlineStr"><is><t xml:space="preserve">12345678901234567890</t></is></c>`,
+		`<mergeCells count="1"><mergeCell ref="A2:A3"/></mergeCells>`,
+	} {
+		if !strings.Contains(string(sheet), want) {
+			t.Errorf("sheet is missing %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestColorMode(t *testing.T) {
+	tests := []struct {
+		mode  ColorMode
+		color bool
+	}{
+		{ColorAlways, true},
+		{ColorAuto, false}, // a bytes.Buffer is not a terminal
+		{ColorNever, false},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetColorMode(tt.mode)
+		table.SetHeader([]string{"Name"})
+		table.SetHeaderColor(Colors{Bold})
+		table.Rich([]string{"A"}, []Colors{{FgRedColor}})
+		table.Render()
+		if got := strings.Contains(buf.String(), ESC); got != tt.color {
+			t.Errorf("mode %d: got color %v, want %v", tt.mode, got, tt.color)
+		}
+	}
+
+	// The mode is applied when printing, not when the row is added.
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColorMode(ColorAlways)
+	table.Rich([]string{"A"}, []Colors{{FgRedColor}})
+	table.SetColorMode(ColorNever)
+	table.Render()
+	if strings.Contains(buf.String(), ESC) {
+		t.Errorf("got color after SetColorMode(ColorNever):\n%q", buf.String())
+	}
+}
+
+func TestMaxWidth(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+----+----------------+----------------+
+| ID |  DESCRIPTION   |     STATUS     |
++----+----------------+----------------+
+|  1 | A fairly long  | ok             |
+|    | description of |                |
+|    | the thing that |                |
+|    | exceeds        |                |
+|  2 | Short          | Supercalifragi |
+|    |                | listic         |
++----+----------------+----------------+
+`
+	)
+	table.SetMaxWidth(40)
+	table.SetAutoTerminalWidth(true) // not a terminal, SetMaxWidth applies
+	table.SetHeader([]string{"ID", "Description", "Status"})
+	table.Append([]string{"1", "A fairly long description of the thing that exceeds", "ok"})
+	table.Append([]string{"2", "Short", "Supercalifragilistic"})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetMaxWidthWidensAgain(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Description"})
+	table.Append([]string{"1", "A fairly long description"})
+	table.SetMaxWidth(20)
+	table.Render()
+	checkEqual(t, strings.SplitN(buf.String(), "\n", 2)[0], "+----+-------------+")
+
+	// Fitting a render leaves the table as appended.
+	buf.Reset()
+	table.SetMaxWidth(0)
+	table.Render()
+	want := "+----+---------------------------+\n" +
+		"| ID |        DESCRIPTION        |\n" +
+		"+----+---------------------------+\n" +
+		"|  1 | A fairly long description |\n" +
+		"+----+---------------------------+\n"
+	checkEqual(t, buf.String(), want)
+
+	// Without white space only the padding surrounds the cells.
+	buf.Reset()
+	table.SetMaxWidth(20)
+	table.SetNoWhiteSpace(true)
+	table.SetTablePadding(" ")
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.Render()
+	widest := 0
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if w := DisplayWidth(line); w > widest {
+			widest = w
+		}
+	}
+	checkEqual(t, widest, 20)
+}
+
+func TestSetColMinWidthKeepsContent(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+--------+---------+--------------+
+| STATUS |  NAME   |     NOTE     |
++--------+---------+--------------+
+| ok     | api-ser | some long    |
+|        | ver     | note text    |
++--------+---------+--------------+
+`
+	)
+	table.SetHeader([]string{"Status", "Name", "Note"})
+	table.Append([]string{"ok", "api-server", "some long note text"})
+	table.SetColMinWidth(0, 2) // narrower than the content, ignored
+	table.SetColMinWidth(2, 12)
+	table.SetMaxWidth(35)
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetColFixedWidths(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+--------+------------+----+
+|  NAME  |    SIGN    | R  |
++--------+------------+----+
+| A      | The Good   |  5 |
+| B      | The Very   | 28 |
+|        | very Bad   |    |
+|        | Man        |    |
++--------+------------+----+
+`
+	)
+	table.SetColFixedWidths(map[int]int{0: 6, 1: 10, 2: 2})
+	table.SetHeader([]string{"Name", "Sign", "R"})
+	table.Append([]string{"A", "The Good", "5"})
+	table.Append([]string{"B", "The Very very Bad Man", "28"})
+	table.Render()
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetColFixedWidths(map[int]int{0: 10})
+	table.SetHeader([]string{"Description"})
+	table.Append([]string{"The Very very Bad Man"})
+	table.Render()
+	checkEqual(t, buf.String(), `+------------+
+| DESCRIP... |
++------------+
+| The Ver... |
++------------+
+`)
+	// Widths of columns the table doesn't have are ignored.
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColFixedWidths(map[int]int{-1: 4, 0: 3, 5: 4})
+	table.Append([]string{"a"})
+	table.Render()
+	checkEqual(t, buf.String(), `+-----+
+| a   |
++-----+
+`)
+}
+
+func TestSetColShrinkPriority(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+----------------------+--------------+--------+
+|          ID          | DESCRIPTION  | STATUS |
++----------------------+--------------+--------+
+| 6f1c2a9e7b4d8a0c3e5f | A fairly     | active |
+|                      | long         |        |
+|                      | description  |        |
+|                      | of the thing |        |
++----------------------+--------------+--------+
+`
+	)
+	table.SetMaxWidth(48)
+	table.SetColShrinkPriority(1, 1)
+	table.SetHeader([]string{"ID", "Description", "Status"})
+	table.Append([]string{"6f1c2a9e7b4d8a0c3e5f", "A fairly long description of the thing", "active"})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetMaxRows(t *testing.T) {
+	data := [][]string{
+		{"A", "The Good", "500"},
+		{"B", "The Very very Bad Man", "288"},
+		{"C", "The Ugly", "120"},
+		{"D", "The Gopher", "800"},
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.SetMaxRows(2)
+	table.AppendBulk(data)
+	table.Render()
+
+	want := `+------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| A    | The Good              |    500 |
+| B    | The Very very Bad Man |    288 |
+| ... and 2 more rows                   |
++------+-----------------------+--------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table.SetRowLine(true)
+	table.SetAutoMergeCells(true)
+	table.SetMaxRows(3)
+	table.Render()
+
+	want = `+------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| A    | The Good              |    500 |
++------+-----------------------+--------+
+| B    | The Very very Bad Man |    288 |
++------+-----------------------+--------+
+| C    | The Ugly              |    120 |
++------+-----------------------+--------+
+| ... and 1 more row                    |
++------+-----------------------+--------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// No column continues into the summary row.
+	buf.Reset()
+	table.SetAutoMergeCells(false)
+	table.SetMaxRows(1)
+	table.SetUnicodeHV(Regular, Regular)
+	table.Render()
+
+	want = `┌──────┬───────────────────────┬────────┐
+│ NAME │         SIGN          │ RATING │
+├──────┼───────────────────────┼────────┤
+│ A    │ The Good              │    500 │
+├──────┴───────────────────────┴────────┤
+│ ... and 3 more rows                   │
+└──────┴───────────────────────┴────────┘
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetNoWhiteSpace(true)
+	table.SetTablePadding("  ")
+	table.SetBorder(false)
+	table.SetMaxRows(2)
+	table.AppendBulk(data)
+	table.Render()
+
+	want = "A  The Good               500  \n" +
+		"B  The Very very Bad Man  288  \n" +
+		"... and 2 more rows            \n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetMaxLines(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+-------+------------+
+| LEVEL |  MESSAGE   |
++-------+------------+
+| error | connection |
+|       | refused    |
+|       | while...   |
+| info  | ok         |
++-------+------------+
+`
+	)
+	table.SetColWidth(10)
+	table.SetMaxLines(3)
+	table.SetHeader([]string{"Level", "Message"})
+	table.Append([]string{"error", "connection refused while dialing the upstream server"})
+	table.Append([]string{"info", "ok"})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+	// The limit applies to rows appended before it is set.
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColWidth(10)
+	table.SetHeader([]string{"Level", "Message"})
+	table.Append([]string{"error", "connection refused while dialing the upstream server"})
+	table.Append([]string{"info", "ok"})
+	table.SetMaxLines(3)
+	table.Render()
+	checkEqual(t, buf.String(), want)
+
+	// The ellipsis of a cut header is kept by the auto format.
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColWidth(6)
+	table.SetHeaderMaxLines(1)
+	table.SetHeader([]string{"long_header text", "b"})
+	table.Append([]string{"x", "y"})
+	table.Render()
+	checkEqual(t, buf.String(), `+-------------+---+
+| LONG HEA... | B |
++-------------+---+
+| x           | y |
++-------------+---+
+`)
+}
+
+func TestSplitWideTable(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+------+--------+----------+
+| NAME |  CPU   |  MEMORY  |
++------+--------+----------+
+| api  | 2 vCPU | 4096 MiB |
+| db   | 8 vCPU | 32 GiB   |
++------+--------+----------+
+
++------+-----------+---------+
+| NAME |  REGION   | STATUS  |
++------+-----------+---------+
+| api  | eu-west-1 | running |
+| db   | us-east-2 | stopped |
++------+-----------+---------+
+`
+	)
+	table.SetMaxWidth(30)
+	table.SetSplitWideTable(true, 0)
+	table.SetHeader([]string{"Name", "CPU", "Memory", "Region", "Status"})
+	table.Append([]string{"api", "2 vCPU", "4096 MiB", "eu-west-1", "running"})
+	table.Append([]string{"db", "8 vCPU", "32 GiB", "us-east-2", "stopped"})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestPrintCaptionTopAligned(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.SetCaption(true, "Short caption.")
+	table.SetCaptionPosition(CAPTION_TOP)
+	table.SetCaptionAlignment(ALIGN_CENTER)
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.Render()
+
+	want := `             Short caption.
++------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| B    | The Very very Bad Man |    288 |
++------+-----------------------+--------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table.SetCaptionPosition(CAPTION_BOTTOM)
+	table.SetCaptionAlignment(ALIGN_RIGHT)
+	table.Render()
+	if !strings.HasSuffix(buf.String(), "+\n                           Short caption.\n") {
+		t.Errorf("caption not right aligned:\n%s", buf.String())
+	}
+}
+
+func TestSetTitle(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetTitle("Ratings")
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.Render()
+
+	want := `+---------------------------------------+
+|                Ratings                |
++------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| B    | The Very very Bad Man |    288 |
++------+-----------------------+--------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetUnicodeHV(Regular, Regular)
+	table.SetTitle("Ratings")
+	table.SetTitleAlignment(ALIGN_LEFT)
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.Render()
+
+	want = `┌─────────────────────────────────┐
+│ Ratings                         │
+├───┬───────────────────────┬─────┤
+│ B │ The Very very Bad Man │ 288 │
+└───┴───────────────────────┴─────┘
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestFootnote(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", table.Footnote("Rating", "Out of 1000")})
+	table.Append([]string{"A", table.Footnote("500", "Estimated from a partial review of the available data")})
+	table.Append([]string{"B", table.Footnote("288", "Out of 1000")})
+	table.Render()
+
+	want := `+------+-----------+
+| NAME | RATING[1] |
++------+-----------+
+| A    | 500[2]    |
+| B    | 288[1]    |
++------+-----------+
+[1] Out of 1000
+[2] Estimated from
+    a partial review
+    of the available
+    data
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetHeaderGroups(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeaderGroups([]HeaderGroup{{"", 1}, {"Engineering", 2}})
+	table.SetHeader([]string{"Quarter", "Backend", "Frontend", "Total"})
+	table.Append([]string{"Q1", "4", "3", "7"})
+	table.Render()
+
+	want := `+---------+--------------------+-------+
+|         |    ENGINEERING     |       |
++---------+---------+----------+-------+
+| QUARTER | BACKEND | FRONTEND | TOTAL |
++---------+---------+----------+-------+
+| Q1      |       4 |        3 |     7 |
++---------+---------+----------+-------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetUnicodeHV(Regular, Regular)
+	table.SetTitle("Headcount")
+	table.SetHeaderGroups([]HeaderGroup{{"Engineering department", 2}})
+	table.SetHeader([]string{"Backend", "Frontend", "Total"})
+	table.Append([]string{"4", "3", "7"})
+	table.Render()
+
+	want = `┌────────────────────────────────┐
+│           Headcount            │
+├────────────────────────┬───────┤
+│ ENGINEERING DEPARTMENT │       │
+├─────────┬──────────────┼───────┤
+│ BACKEND │   FRONTEND   │ TOTAL │
+├─────────┼──────────────┼───────┤
+│       4 │            3 │     7 │
+└─────────┴──────────────┴───────┘
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAppendCells(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.AppendCells([]Cell{
+		{Text: "C", Align: ALIGN_RIGHT},
+		{Text: "The Ugly", Align: ALIGN_CENTER},
+		{Text: "120", Align: ALIGN_LEFT, Colors: Colors{FgRedColor}},
+	})
+	table.Render()
+
+	want := `+------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| B    | The Very very Bad Man |    288 |
+|    C |       The Ugly        | ` + "\033[31m120\033[0m" + `    |
++------+-----------------------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetNoMergeColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetAutoMergeCells(true)
+	table.SetNoMergeColumns(1)
+	table.AppendBulk([][]string{
+		{"A", "x", "1"},
+		{"A", "x", "1"},
+	})
+	table.Render()
+
+	want := `+---+---+---+
+| A | x | 1 |
+|   | x |   |
++---+---+---+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetMergeComparator(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+	table.SetMergeComparator(strings.EqualFold)
+	table.SetColumnMergeComparator(1, func(a, b string) bool {
+		return strings.SplitN(a, "-", 2)[0] == strings.SplitN(b, "-", 2)[0]
+	})
+	table.AppendBulk([][]string{
+		{"Go", "EU-1", "1"},
+		{"GO", "EU-2", "2"},
+		{"Rust", "US-1", "2"},
+	})
+	table.Render()
+
+	want := `+------+------+---+
+| Go   | EU-1 | 1 |
++      +      +---+
+|      |      | 2 |
++------+------+   +
+| Rust | US-1 |   |
++------+------+---+
+`
+	checkEqual(t, buf.String(), want)
+
+	// Cells are compared with the first cell of their run, not the one above.
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetAutoMergeCells(true)
+	table.SetMergeComparator(func(a, b string) bool {
+		x, _ := strconv.Atoi(a)
+		y, _ := strconv.Atoi(b)
+		return y-x <= 1
+	})
+	table.AppendBulk([][]string{{"1"}, {"2"}, {"3"}})
+	table.Render()
+
+	want = `+---+
+| 1 |
+|   |
+| 3 |
++---+
+`
+	checkEqual(t, buf.String(), want)
+	sheet := string(table.xlsxSheet())
+	if !strings.Contains(sheet, `<mergeCell ref="A1:A2"/>`) {
+		t.Errorf("got sheet merges %s, want A1:A2", sheet)
+	}
+}
+
+func TestAppendFrom(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	ch := make(chan []string, 2)
+	ch <- []string{"A", "1"}
+	ch <- []string{"B", "2"}
+	close(ch)
+	if err := table.AppendFrom(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+---+---+
+| A | 1 |
+| B | 2 |
++---+---+
+`
+	checkEqual(t, buf.String(), want)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := table.AppendFrom(ctx, make(chan []string)); err != context.Canceled {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSetThreadSafe(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetThreadSafe(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				table.Append([]string{fmt.Sprint(i), fmt.Sprint(j)})
+			}
+		}(i)
+	}
+	wg.Wait()
+	table.Render()
+
+	if got := table.NumLines(); got != 400 {
+		t.Errorf("got %d rows, want 400", got)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != 402 {
+		t.Errorf("got %d lines, want 402", got)
+	}
+}
+
+func TestNewCSVWithOptions(t *testing.T) {
+	data := `# exported ids
+name;id
+John;123456
+Kathy;N/A
+Bob;3979870
+Ann;1
+`
+	var buf bytes.Buffer
+	table, err := NewCSVWithOptions(&buf, strings.NewReader(data), CSVOptions{
+		Comma:      ';',
+		Comment:    '#',
+		HasHeader:  true,
+		MaxRows:    3,
+		SniffTypes: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+-------+---------+
+| NAME  |   ID    |
++-------+---------+
+| John  | 123456  |
+| Kathy | N/A     |
+| Bob   | 3979870 |
++-------+---------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table, err = NewCSVWithOptions(&buf, strings.NewReader("a,1\nb,22\n"), CSVOptions{SniffTypes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want = `+---+----+
+| a |  1 |
+| b | 22 |
++---+----+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAppendMap(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Age", "Note"})
+	if err := table.AppendMap(map[string]interface{}{"Age": 13, "Name": "Gopher", "Extra": true}); err != nil {
+		t.Fatal(err)
+	}
+	table.SetStrictMapKeys(true)
+	if err := table.AppendMap(map[string]interface{}{"Name": "Ferris", "Extra": true}); err == nil {
+		t.Error("expected error for unknown key")
+	}
+	if err := table.AppendMap(map[string]interface{}{"Name": "Ferris", "Note": nil}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+--------+-----+------+
+|  NAME  | AGE | NOTE |
++--------+-----+------+
+| Gopher |  13 |      |
+| Ferris |     |      |
++--------+-----+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestNewRow(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Status"})
+	row := table.NewRow()
+	row.Set("Status", "ok")
+	row.Set("Name", "api")
+	if err := row.Set("Region", "eu"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	table.AppendRow(row)
+	table.Render()
+
+	want := `+------+--------+
+| NAME | STATUS |
++------+--------+
+| api  | ok     |
++------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAddComputedColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.AddComputedColumn("Ratio", func(row []string) string {
+		hits, _ := strconv.ParseFloat(row[1], 64)
+		total, _ := strconv.ParseFloat(row[2], 64)
+		return fmt.Sprintf("%.2f", hits/total)
+	})
+	table.SetHeader([]string{"Name", "Hits", "Total"})
+	table.Append([]string{"api", "3", "4"})
+	table.AppendMap(map[string]interface{}{"Name": "web", "Hits": 1, "Total": 8})
+	row := table.NewRow()
+	row.Set("Name", "db")
+	row.Set("Hits", "2")
+	row.Set("Total", "2")
+	if err := row.Set("Ratio", "1"); err == nil {
+		t.Error("got no error setting a computed column")
+	}
+	table.AppendRow(row)
+	table.Render()
+
+	want := `+------+------+-------+-------+
+| NAME | HITS | TOTAL | RATIO |
++------+------+-------+-------+
+| api  |    3 |     4 |  0.75 |
+| web  |    1 |     8 |  0.12 |
+| db   |    2 |     2 |  1.00 |
++------+------+-------+-------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetColumnFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Took", "Ok"})
+	table.SetColumnFormatter(2, func(v interface{}) string {
+		if v == true {
+			return "yes"
+		}
+		return "no"
+	})
+	table.AppendAny([]interface{}{"build", 1500 * time.Millisecond, true})
+	table.AppendAny([]interface{}{"test", nil, false})
+	table.Render()
+
+	want := `+-------+------+-----+
+| NAME  | TOOK | OK  |
++-------+------+-----+
+| build | 1.5s | yes |
+| test  |      | no  |
++-------+------+-----+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetColumnNumberFormat(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColumnNumberFormat(1, ",", ".")
+	table.SetColumnNumberFormat(2, ".", ",")
+	table.AppendBulk([][]string{
+		{"a", "1234567", "-9876.25"},
+		{"b", "12", "n/a"},
+	})
+	table.Render()
+
+	want := `+---+-----------+-----------+
+| a | 1,234,567 | -9.876,25 |
+| b |        12 | n/a       |
++---+-----------+-----------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetTimeLayout(t *testing.T) {
+	now := time.Date(2020, 5, 17, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.now = func() time.Time { return now }
+	table.SetTimeLayout("2006-01-02")
+	table.SetColumnTimeLayout(1, TimeRelative)
+	table.AppendAny([]interface{}{now, now.Add(-90 * time.Minute)})
+	table.AppendAny([]interface{}{now.AddDate(0, 0, -3), now.Add(3 * 24 * time.Hour)})
+	table.AppendAny([]interface{}{now, now.Add(-10 * time.Second)})
+	table.Render()
+
+	want := `+------------+------------+
+| 2020-05-17 | 1 hour ago |
+| 2020-05-14 | in 3 days  |
+| 2020-05-17 | just now   |
++------------+------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetNumericColumnAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetNumericColumnAlignment(true, true, false)
+	table.SetHeader([]string{"Name", "Code", "Sum"})
+	table.SetFooter([]string{"", "Total", "1,250"})
+	table.AppendBulk([][]string{
+		{"a", "404", "1,000"},
+		{"b", "E42", "250"},
+	})
+	table.Render()
+
+	want := `+------+-------+-------+
+| NAME | CODE  |   SUM |
++------+-------+-------+
+| a    | 404   | 1,000 |
+| b    | E42   |   250 |
++------+-------+-------+
+|        TOTAL | 1,250 |
++------+-------+-------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetGroupBy(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Region", "Host", "Requests"})
+	table.SetGroupBy(0)
+	table.SetGroupSubtotals(true, 2)
+	table.AppendBulk([][]string{
+		{"eu", "web-1", "1,200"},
+		{"us", "web-2", "300"},
+		{"eu", "web-3", "800"},
+	})
+	table.Render()
+
+	want := `+----------+-------+----------+
+|  REGION  | HOST  | REQUESTS |
++----------+-------+----------+
+| eu                          |
++----------+-------+----------+
+|          | web-1 |    1,200 |
+|          | web-3 |      800 |
++----------+-------+----------+
+| Subtotal |       |    2,000 |
++----------+-------+----------+
+| us                          |
++----------+-------+----------+
+|          | web-2 |      300 |
++----------+-------+----------+
+| Subtotal |       |      300 |
++----------+-------+----------+
+|  TOTAL   |          2,300   |
++----------+-------+----------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// Turning grouping off drops the grand totals.
+	buf.Reset()
+	table.SetGroupBy(-1)
+	table.Render()
+	want = `+--------+-------+----------+
+| REGION | HOST  | REQUESTS |
++--------+-------+----------+
+| eu     | web-1 |    1,200 |
+| us     | web-2 |      300 |
+| eu     | web-3 |      800 |
++--------+-------+----------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// A footer set by the caller is kept.
+	buf.Reset()
+	table.SetGroupBy(0)
+	table.SetFooter([]string{"", "", "n/a"})
+	table.Render()
+	if !strings.Contains(buf.String(), "N/A") || strings.Contains(buf.String(), "TOTAL") {
+		t.Errorf("footer replaced by totals:\n%s", buf.String())
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	data := [][]string{
+		{"b", "10"},
+		{"a", "9"},
+		{"c", "10"},
+		{"a", "100"},
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.AppendBulk(data)
+	table.SortBy(1, true)
+	table.Render()
+
+	want := `+---+-----+
+| a | 100 |
+| b |  10 |
+| c |  10 |
+| a |   9 |
++---+-----+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table.SortByKeys(SortKey{Column: 0}, SortKey{Column: 1, Less: func(a, b string) bool { return a < b }})
+	table.Render()
+
+	want = `+---+-----+
+| a | 100 |
+| a |   9 |
+| b |  10 |
+| c |  10 |
++---+-----+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetRowFilter(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetRowFilter(func(cells []string, idx int) bool {
+		return cells[1] != "0"
+	})
+	table.AppendBulk([][]string{
+		{"a", "0"},
+		{"b", "2"},
+		{"c", "0"},
+		{"d", "4"},
+	})
+	table.Render()
+
+	want := `+---+---+
+| b | 2 |
+| d | 4 |
++---+---+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestRenderPage(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Id"})
+	table.AppendBulk([][]string{
+		{"longest", "1"},
+		{"b", "2"},
+		{"c", "3"},
+	})
+	checkEqual(t, table.NumPages(2), 2)
+	if err := table.RenderPage(2, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `+---------+----+
+|  NAME   | ID |
++---------+----+
+| c       |  3 |
++---------+----+
+`
+	checkEqual(t, buf.String(), want)
+
+	if err := table.RenderPage(0, 2); err == nil {
+		t.Error("expected error for page 0")
+	}
+}
+
+func TestSetTranspose(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetTranspose(true)
+	table.SetHeader([]string{"Name", "Region", "Requests"})
+	table.Append([]string{"web-1", "eu", "1200"})
+	table.Render()
+
+	want := `+----------+-------+
+| NAME     | web-1 |
+| REGION   | eu    |
+| REQUESTS |  1200 |
++----------+-------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// Row settings of the table don't apply to the transposed rows.
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetTranspose(true)
+	table.SetRowTransform(func(rowIdx int, cells []string) []string {
+		cells[0] += "*"
+		return cells
+	})
+	table.SetHeader([]string{"Name", "Region"})
+	table.Append([]string{"web-1", "eu"})
+	table.Render()
+
+	want = `+--------+--------+
+| NAME   | web-1* |
+| REGION | eu     |
++--------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetVisibleColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Status", "Node", "Age"})
+	table.Append([]string{"api", "Running", "node-1", "3d"})
+	table.SetVisibleColumns("Status", "Name", "Age")
+	table.SetHiddenColumns(3)
+	table.Render()
+
+	want := `+---------+------+
+| STATUS  | NAME |
++---------+------+
+| Running | api  |
++---------+------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table.SetVisibleColumns()
+	table.Render()
+
+	want = `+------+---------+--------+
+| NAME | STATUS  |  NODE  |
++------+---------+--------+
+| api  | Running | node-1 |
++------+---------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetTreeColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Size"})
+	table.SetTreeColumn(0)
+	table.AppendTree(0, []string{"src", "4"})
+	table.AppendTree(1, []string{"table.go", "3"})
+	table.AppendTree(1, []string{"util", "1"})
+	table.AppendTree(2, []string{"wrap.go", "1"})
+	table.AppendTree(1, []string{"main.go", "1"})
+	table.AppendTree(0, []string{"README.md", "1"})
+	table.Render()
+
+	want := `+-----------------+------+
+|      NAME       | SIZE |
++-----------------+------+
+| src             |    4 |
+| ├── table.go    |    3 |
+| ├── util        |    1 |
+| │   └── wrap.go |    1 |
+| └── main.go     |    1 |
+| README.md       |    1 |
++-----------------+------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// The lines follow the rows as printed.
+	buf.Reset()
+	table.SetRowFilter(func(cells []string, idx int) bool { return cells[0] != "main.go" })
+	table.Render()
+	want = `+-----------------+------+
+|      NAME       | SIZE |
++-----------------+------+
+| src             |    4 |
+| ├── table.go    |    3 |
+| └── util        |    1 |
+|     └── wrap.go |    1 |
+| README.md       |    1 |
++-----------------+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAppendCellsNestedTable(t *testing.T) {
+	child := NewWriter(nil)
+	child.SetHeader([]string{"Disk", "Free"})
+	child.Append([]string{"sda", "10%"})
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Host", "Disks"})
+	table.AppendCells([]Cell{{Text: "web-1"}, {Table: child}})
+	table.Render()
+
+	want := `+-------+-----------------+
+| HOST  |      DISKS      |
++-------+-----------------+
+| web-1 | +------+------+ |
+|       | | DISK | FREE | |
+|       | +------+------+ |
+|       | | sda  | 10%  | |
+|       | +------+------+ |
++-------+-----------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetRowStripes(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Id"})
+	table.SetRowStripes(Colors{}, Colors{BgBlackColor})
+	table.SetColumnColor(Colors{}, Colors{FgRedColor})
+	table.AppendBulk([][]string{
+		{"a", "1"},
+		{"b", "2"},
+		{"c", "3"},
+	})
+	table.Render()
+
+	want := "+------+----+\n" +
+		"| NAME | ID |\n" +
+		"+------+----+\n" +
+		"| a    | \033[31m1\033[0m  |\n" +
+		"| \033[40mb   \033[0m | \033[40m\033[31m2\033[0m\033[40m \033[0m |\n" +
+		"| c    | \033[31m3\033[0m  |\n" +
+		"+------+----+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAppendStyled(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.Append([]string{"disk", "95%"})
+	table.AppendStyled([]string{"cpu", "99%"}, RowStyle{Align: ALIGN_RIGHT, Colors: Colors{Bold, FgRedColor}})
+	table.Render()
+
+	want := "+------+-----+\n" +
+		"| disk | 95% |\n" +
+		"|  \033[1;31mcpu\033[0m | \033[1;31m99%\033[0m |\n" +
+		"+------+-----+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetCellCallback(t *testing.T) {
+	var got []CellContext
+	table := NewWriter(ioutil.Discard)
+	table.SetHeader([]string{"name", "id"})
+	table.Append([]string{"gopher", "1"})
+	table.SetCellCallback(func(c CellContext) {
+		got = append(got, c)
+	})
+	table.Render()
+
+	want := []CellContext{
+		{Section: SectionHeader, Row: -1, Column: 0, Value: "NAME", Width: 6},
+		{Section: SectionHeader, Row: -1, Column: 1, Value: "ID", Width: 2},
+		{Section: SectionRow, Row: 0, Column: 0, Value: "gopher", Width: 6},
+		{Section: SectionRow, Row: 0, Column: 1, Value: "1", Width: 2},
+	}
+	checkEqual(t, got, want)
+}
+
+func TestSetRowTransform(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetRowTransform(func(rowIdx int, cells []string) []string {
+		out := append([]string{}, cells...)
+		if cells[1] == "down" {
+			out[0] = "!" + cells[0]
+		}
+		return append(out, strconv.Itoa(rowIdx+1))
+	})
+	table.Append([]string{"api", "up"})
+	table.Append([]string{"db", "down"})
+	table.Render()
+
+	want := `+-----+------+---+
+| api | up   | 1 |
+| !db | down | 2 |
++-----+------+---+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetCellHook(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "State"})
+	table.Append([]string{"api", "FAIL"})
+	table.Append([]string{"db", "ok"})
+	table.SetCellHook(func(ctx CellContext, cell string) string {
+		if ctx.Section == SectionRow && ctx.Value == "FAIL" {
+			return strings.Replace(cell, "FAIL", "fail", 1)
+		}
+		return cell
+	})
+	table.Render()
+
+	want := `+------+-------+
+| NAME | STATE |
++------+-------+
+| api  | fail  |
+| db   | ok    |
++------+-------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetSymbols(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Id"})
+	if err := table.SetSymbols(SymbolsRounded); err != nil {
+		t.Fatal(err)
+	}
+	table.Append([]string{"gopher", "1"})
+	table.Render()
+
+	want := `
+╭────────┬────╮
+│  NAME  │ ID │
+├────────┼────┤
+│ gopher │  1 │
+╰────────┴────╯
+`
+	checkEqual(t, buf.String(), want)
+
+	if err := table.SetSymbols("wavy"); err == nil {
+		t.Error("expected error for unknown symbol set")
+	}
+}
+
+func TestSetSectionSymbols(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Id"})
+	table.SetFooter([]string{"Total", "3"})
+	table.SetRowLine(true)
+	if err := table.SetSymbols(SymbolsLight); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetSectionSymbols(SectionHeader, SymbolsHeavyHorizontal); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetSectionSymbols(SectionRow, SymbolsDashed); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetSectionSymbols(SectionFooter, SymbolsDoubleHorizontal); err != nil {
+		t.Fatal(err)
+	}
+	table.Append([]string{"gopher", "1"})
+	table.Append([]string{"gonzo", "2"})
+	table.Render()
+
+	want := `
+┌────────┬────┐
+│  NAME  │ ID │
+┝━━━━━━━━┿━━━━┥
+│ gopher │  1 │
+├┄┄┄┄┄┄┄┄┼┄┄┄┄┤
+│ gonzo  │  2 │
+╞════════╪════╡
+│ TOTAL  │ 3  │
+└────────┴────┘
+`
+	checkEqual(t, buf.String(), want)
+
+	if err := table.SetSectionSymbols(SectionRow, "wavy"); err == nil {
+		t.Error("expected error for unknown symbol set")
+	}
+}
+
+func TestAppendSeparator(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Env", "Host"})
+	table.Append([]string{"prod", "web-1"})
+	table.Append([]string{"prod", "web-2"})
+	table.AppendSeparator()
+	table.Append([]string{"dev", "web-3"})
+	table.AppendSeparator()
+	table.Render()
+
+	want := `
++------+-------+
+| ENV  | HOST  |
++------+-------+
+| prod | web-1 |
+| prod | web-2 |
++------+-------+
+| dev  | web-3 |
++------+-------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAppendDivider(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Host", "Status"})
+	table.AppendDivider("Production", RowStyle{Align: ALIGN_CENTER})
+	table.Append([]string{"web-1", "up"})
+	table.Append([]string{"web-2", "up"})
+	table.AppendDivider("Staging", RowStyle{Align: ALIGN_LEFT})
+	table.Append([]string{"web-3", "down"})
+	table.Render()
+
+	want := `
++-------+--------+
+| HOST  | STATUS |
++-------+--------+
+| - Production - |
++-------+--------+
+| web-1 | up     |
+| web-2 | up     |
++-------+--------+
+| -- Staging --- |
++-------+--------+
+| web-3 | down   |
++-------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetVerticalHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetVerticalHeader(true)
+	table.SetHeader([]string{"Day", "Runs"})
+	table.Append([]string{"1", "12"})
+	table.Append([]string{"2", "7"})
+	table.Render()
+
+	want := `
++---+----+
+| D | R  |
+| A | U  |
+| Y | N  |
+|   | S  |
++---+----+
+| 1 | 12 |
+| 2 |  7 |
++---+----+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetRightToLeft(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetHeader([]string{"שם", "מספר"})
+	table.SetRightToLeft(true)
+	if err := table.SetSymbols(SymbolsRounded); err != nil {
+		t.Fatal(err)
+	}
+	table.Append([]string{"‏דני", "1"})
+	table.Append([]string{"רות", "22"})
+	table.Render()
+
+	want := `
+╭──────┬─────╮
+│ מספר │ שם  │
+├──────┼─────┤
+│    1 │ ‏דני │
+│   22 │ רות │
+╰──────┴─────╯
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetTabWidth(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetTabWidth(4)
+	table.SetHeader([]string{"Code"})
+	table.Append([]string{"if x {\n\treturn\n}"})
+	table.Append([]string{"ab\tc"})
+	table.Render()
+
+	want := `
++------------+
+|    CODE    |
++------------+
+| if x {     |
+|     return |
+| }          |
+| ab  c      |
++------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetTruncatePosition(t *testing.T) {
+	for _, tt := range []struct {
+		position int
+		want     string
+	}{
+		{TRUNCATE_END, "| /usr/lo... |"},
+		{TRUNCATE_START, "| ...main.go |"},
+		{TRUNCATE_MIDDLE, "| /usr....go |"},
+	} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoWrapText(false)
+		table.SetTruncatePosition(tt.position)
+		table.SetColFixedWidths(map[int]int{0: 10})
+		table.Append([]string{"/usr/local/src/main.go"})
+		table.Render()
+		checkEqual(t, strings.Split(buf.String(), "\n")[1], tt.want)
+	}
+}
+
+func TestSetEllipsis(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetEllipsis("»")
+	table.SetColumnEllipsis(1, "")
+	table.SetColFixedWidths(map[int]int{0: 6, 1: 6})
+	table.Append([]string{"The Good Man", "The Bad Man"})
+	table.Render()
+	checkEqual(t, strings.Split(buf.String(), "\n")[1], "| The G» | The Ba |")
+}
+
+func TestCRLF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetNewLine("\r\n")
+	table.SetAutoWrapText(false)
+	table.SetCaption(true, "Hosts")
+	table.Append([]string{"web-1\r\nweb-2"})
+	table.Render()
+
+	want := "+-------+\r\n" +
+		"| web-1 |\r\n" +
+		"| web-2 |\r\n" +
+		"+-------+\r\n" +
+		"Hosts\r\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetKeepSpaceColumns(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Path", "Note"})
+	table.SetKeepSpaceColumns(0)
+	table.Append([]string{"src", "  two  spaces"})
+	table.Append([]string{"  main.go", "entry"})
+	table.Render()
+
+	want := `
++-----------+---------------+
+|   PATH    |     NOTE      |
++-----------+---------------+
+| src       | two spaces    |
+|   main.go | entry         |
++-----------+---------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetStrict(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteRune('\n') // Makes the want literal easier to read.
+
+	table := NewWriter(buf)
+	table.SetStrict(true)
+	table.SetHeader([]string{"Name", "Id"})
+	table.Append([]string{"gopher", "1"})
+	table.Append([]string{"gonzo"})
+	table.Append([]string{"gizmo", "3", "extra"})
+	table.SetFooter([]string{"Total"})
+	table.Render()
+
+	want := `
++--------+----+
+|  NAME  | ID |
++--------+----+
+| gopher |  1 |
++--------+----+
+`
+	checkEqual(t, buf.String(), want)
+	if err := table.Err(); err == nil || err.Error() != "row 1 has 1 cells, want 2" {
+		t.Errorf("unexpected error %v", err)
+	}
+	// Rich rows are checked too.
+	table = NewWriter(ioutil.Discard)
+	table.SetStrict(true)
+	table.SetHeader([]string{"Name", "Id"})
+	table.Rich([]string{"gizmo", "3", "extra"}, []Colors{{FgRedColor}})
+	checkEqual(t, table.NumLines(), 0)
+	checkEqual(t, len(table.rowColors), 0)
+	if err := table.Err(); err == nil || err.Error() != "row 0 has 3 cells, want 2" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	template := NewWriter(ioutil.Discard)
+	template.SetHeader([]string{"Name", "Id"})
+	template.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT})
+	template.SetBorder(false)
+	template.Append([]string{"a much longer name", "1"})
+
+	var wg sync.WaitGroup
+	bufs := make([]bytes.Buffer, 4)
+	for i := range bufs {
+		table := template.Clone(&bufs[i], false)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			table.Append([]string{"gopher", strconv.Itoa(i)})
+			table.Render()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range bufs {
+		want := "   NAME  | ID  \n" +
+			"---------+-----\n" +
+			"  gopher |  " + strconv.Itoa(i) + "  \n"
+		checkEqual(t, bufs[i].String(), want)
+	}
+
+	buf := &bytes.Buffer{}
+	table := template.Clone(buf, true)
+	table.Append([]string{"gonzo", "2"})
+	table.Render()
+	template.Render()
+	checkEqual(t, strings.Count(buf.String(), "\n"), 4)
+
+	// Footnotes marked in the rows left out are dropped.
+	template = NewWriter(ioutil.Discard)
+	template.SetHeader([]string{"Name", template.Footnote("Id", "Unique")})
+	template.Append([]string{"a", template.Footnote("1", "Reused")})
+	template.SetLive(true)
+	template.Render()
+	buf.Reset()
+	table = template.Clone(buf, false)
+	table.Append([]string{"b", "2"})
+	table.Render()
+	want := `+------+-------+
+| NAME | ID[1] |
++------+-------+
+| b    |     2 |
++------+-------+
+[1] Unique
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestReset(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Id"})
+	table.Append([]string{"a much longer name", "1"})
+	table.SetFooter([]string{"Total", "1"})
+	table.Render()
+
+	buf.Reset()
+	table.Reset()
+	table.SetHeader([]string{"Host"})
+	table.Append([]string{"web-1"})
+	table.Render()
+	want := `+-------+
+| HOST  |
++-------+
+| web-1 |
++-------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table.ClearHeader()
+	table.Render()
+	checkEqual(t, buf.String(), `+-------+
+| web-1 |
++-------+
+`)
+}
+
+func TestAccessors(t *testing.T) {
+	table := NewWriter(ioutil.Discard)
+	table.SetHeader([]string{"Name", "Id"})
+	table.Append([]string{"a very long name that wraps", "1"})
+	table.Append([]string{"gopher", "2"})
+
+	checkEqual(t, table.NumLines(), 2)
+	checkEqual(t, table.RowCount(), 2)
+	checkEqual(t, table.Headers(), []string{"Name", "Id"})
+	rows := table.Rows()
+	checkEqual(t, rows, [][]string{{"a very long name that wraps", "1"}, {"gopher", "2"}})
+	rows[1][0] = "changed"
+	checkEqual(t, table.Rows()[1][0], "gopher")
+}
+
+func TestEditRows(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Id"})
+	table.Append([]string{"a much longer name", "1"})
+	table.Append([]string{"gopher", "2"})
+	table.AppendSeparator()
+	table.Rich([]string{"gonzo", "3"}, []Colors{{}, {FgRedColor}})
+
+	if err := table.RemoveRow(0); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.InsertRow(1, []string{"gizmo", "4"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.UpdateCell(0, 0, "Gopher"); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.UpdateRow(2, []string{"Gonzo", "5"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.RemoveRow(3); err == nil {
+		t.Error("expected error for row out of range")
+	}
+	table.Render()
+
+	want := "+--------+----+\n" +
+		"|  NAME  | ID |\n" +
+		"+--------+----+\n" +
+		"| Gopher |  2 |\n" +
+		"+--------+----+\n" +
+		"| gizmo  |  4 |\n" +
+		"| Gonzo  | \033[31m5\033[0m  |\n" +
+		"+--------+----+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestString(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name"})
+	table.Append([]string{"gopher"})
+
+	want := `+--------+
+|  NAME  |
++--------+
+| gopher |
++--------+
+`
+	checkEqual(t, fmt.Sprintf("%s", table), want)
+	checkEqual(t, buf.Len(), 0)
+}
+
+func TestRenderString(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetStrict(true)
+	table.SetHeader([]string{"Name"})
+	table.Append([]string{"gopher"})
+
+	s, err := table.RenderString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, s, table.String())
+	checkEqual(t, buf.Len(), 0)
+
+	table.Append([]string{"gonzo", "2"})
+	if _, err := table.RenderString(); err == nil {
+		t.Error("expected error for the rejected row")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	table := NewWriter(ioutil.Discard)
+	table.SetHeader([]string{"Env", "Host"})
+	table.SetAutoMergeCells(true)
+	table.SetHiddenColumns(2)
+	table.Append([]string{"prod", "web-1", "x"})
+	table.Append([]string{"prod", "web-2", "y"})
+	table.SortBy(1, true)
+
+	s := table.Snapshot()
+	checkEqual(t, s.Widths, []int{4, 5})
+	checkEqual(t, s.Header, [][]string{{"ENV"}, {"HOST"}})
+	checkEqual(t, s.Rows, []SnapshotRow{
+		{Index: 1, Cells: [][]string{{"prod"}, {"web-2"}}, Merged: []bool{false, false}},
+		{Index: 0, Cells: [][]string{{"prod"}, {"web-1"}}, Merged: []bool{true, false}},
+	})
+	checkEqual(t, len(s.Footer), 0)
+}
+
+func TestLayoutInfo(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Note", "Id"})
+	table.SetHiddenColumns(1)
+	table.SetColWidth(6)
+	table.Append([]string{"gopher", "x", "1"})
+	table.Append([]string{"the gonzo", "y", "2"})
+	table.Render()
+
+	layout := table.LayoutInfo()
+	checkEqual(t, layout.Widths, []int{6, 2})
+	checkEqual(t, layout.Offsets, []int{2, 11})
+	checkEqual(t, layout.Hidden, []int{1})
+	checkEqual(t, layout.HeaderHeight, 1)
+	checkEqual(t, layout.Heights, []int{1, 2})
+	checkEqual(t, layout.FooterHeight, 0)
+
+	line := strings.Split(buf.String(), "\n")[3]
+	checkEqual(t, layout.Width, DisplayWidth(line))
+	checkEqual(t, line[layout.Offsets[1]:layout.Offsets[1]+layout.Widths[1]], " 1")
+}
+
+func TestRenderTo(t *testing.T) {
+	table := NewWriter(ioutil.Discard)
+	table.SetHeader([]string{"Name"})
+	table.Append([]string{"gopher"})
+
+	var a, b bytes.Buffer
+	if err := table.RenderTo(&a, &b); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, a.String(), table.String())
+	checkEqual(t, b.String(), a.String())
+
+	if err := table.RenderTo(&a, errWriter{}); err == nil {
+		t.Error("expected write error")
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestSetLive(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetLive(true)
+	table.SetBorder(false)
+	table.Append([]string{"1"})
+	table.Render()
+	table.ClearRows()
+	table.Append([]string{"2"})
+	table.Render()
+
+	checkEqual(t, buf.String(), "  1  \n\033[1A\033[J  2  \n")
+
+	// Colors are decided by the terminal the buffered render is for.
+	tty, err := os.Open(os.DevNull)
+	if err != nil || !isTerminal(tty) {
+		t.Skip("no character device to stand for a terminal")
+	}
+	defer tty.Close()
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetHeader([]string{"Name"})
+	table.SetHeaderColor(Colors{Bold})
+	table.term = tty
+	table.Render()
+	if !strings.Contains(buf.String(), ESC) {
+		t.Errorf("got no colors for a terminal:\n%s", buf.String())
+	}
+}
+
+func TestWatch(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorder(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	err := table.Watch(ctx, time.Millisecond, func(table *Table) {
+		n++
+		table.Append([]string{strings.Repeat(strconv.Itoa(n), 4-n)})
+		if n == 3 {
+			cancel()
+		}
+	})
+	checkEqual(t, err, context.Canceled)
+	// Columns narrow again when the rows do.
+	checkEqual(t, buf.String(), "  111  \n\033[1A\033[J  22  \n\033[1A\033[J  3  \n")
+}
+
+func TestSetColumnHeatMap(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Host", "Load"})
+	table.SetColumnHeatMap(1)
+	table.AppendBulk([][]string{
+		{"a", "10"},
+		{"b", "n/a"},
+		{"c", "55"},
+		{"d", "100"},
+	})
+	table.Render()
+
+	want := "+------+------+\n" +
+		"| HOST | LOAD |\n" +
+		"+------+------+\n" +
+		"| a    | \033[42m10\033[0m   |\n" +
+		"| b    | n/a  |\n" +
+		"| c    | \033[43m55\033[0m   |\n" +
+		"| d    | \033[41m100\033[0m  |\n" +
+		"+------+------+\n"
+	checkEqual(t, buf.String(), want)
+
+	// The heat map follows its column when other columns are hidden.
+	buf.Reset()
+	table.SetColumnHeatMap(1, Colors{FgBlueColor}, Colors{FgRedColor})
+	table.SetVisibleColumns("Load")
+	table.Render()
+	want = "+------+\n" +
+		"| LOAD |\n" +
+		"+------+\n" +
+		"| \033[34m10\033[0m   |\n" +
+		"| n/a  |\n" +
+		"| \033[31m55\033[0m   |\n" +
+		"| \033[31m100\033[0m  |\n" +
+		"+------+\n"
+	checkEqual(t, buf.String(), want)
+	// Cells outside the range last seen take the color of its nearest end.
+	table = NewWriter(&buf)
+	table.SetColumnHeatMap(0)
+	table.AppendBulk([][]string{{"1"}, {"2"}})
+	table.Render()
+	checkEqual(t, table.UpdateCell(1, 0, "100"), nil)
+	checkEqual(t, table.UpdateCell(0, 0, "-5"), nil)
+	buf.Reset()
+	checkEqual(t, table.RenderRow(&buf, 1), nil)
+	checkEqual(t, buf.String(), "| \033[41m100\033[0m |\n")
+	buf.Reset()
+	checkEqual(t, table.RenderRow(&buf, 0), nil)
+	checkEqual(t, buf.String(), "| \033[42m-5\033[0m  |\n")
+}
+
+func TestSetColumnIcons(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Check", "Status"})
+	table.SetColumnIcons(1, map[string]string{"ok": "✓", "fail": "✗", "warn": "⚠️"})
+	table.AppendBulk([][]string{
+		{"disk", "ok"},
+		{"cpu", "warn"},
+		{"net", "fail"},
+		{"dns", "unknown"},
+	})
+	table.Render()
+
+	want := "+-------+---------+\n" +
+		"| CHECK | STATUS  |\n" +
+		"+-------+---------+\n" +
+		"| disk  | ✓       |\n" +
+		"| cpu   | ⚠️      |\n" +
+		"| net   | ✗       |\n" +
+		"| dns   | unknown |\n" +
+		"+-------+---------+\n"
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.Rows()[0][1], "✓")
+}
+
+func TestSetBorderColor(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Id"})
+	table.SetBorderColor(Colors{FgHiBlackColor})
+	table.Append([]string{"a", "1"})
+	table.Render()
+
+	c := func(s string) string { return "\033[90m" + s + "\033[0m" }
+	line := c("+------+----+") + "\n"
+	want := line +
+		c("|") + " NAME " + c("|") + " ID " + c("|") + "\n" +
+		line +
+		c("|") + " a    " + c("|") + "  1 " + c("|") + "\n" +
+		line
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table.ClearRows()
+	table.AppendDivider("db", RowStyle{Align: ALIGN_LEFT})
+	table.Append([]string{"a", "1"})
+	table.Render()
+	lines := strings.Split(buf.String(), "\n")
+	checkEqual(t, len(lines), 8)
+	checkEqual(t, lines[3], c("|")+" "+c("--")+" db "+c("---")+" "+c("|"))
+
+	buf.Reset()
+	table.SetColorMode(ColorNever)
+	table.Render()
+	checkEqual(t, strings.Contains(buf.String(), "\033"), false)
+}
+
+func TestSetColumnSeparatorAfter(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"", "Check", "Time"})
+	table.SetFooter([]string{"#", "Total", "2"})
+	table.SetColumnSeparatorAfter(0, false)
+	table.AppendBulk([][]string{{"✓", "disk", "1"}, {"✗", "net", "1"}})
+	table.Render()
+
+	want := "+-----------+------+\n" +
+		"|     CHECK | TIME |\n" +
+		"+-----------+------+\n" +
+		"| ✓   disk  |    1 |\n" +
+		"| ✗   net   |    1 |\n" +
+		"+-----------+------+\n" +
+		"| #   TOTAL |  2   |\n" +
+		"+-----------+------+\n"
+	checkEqual(t, buf.String(), want)
+
+	// The columns stay joined when mirrored.
+	buf.Reset()
+	table.SetRightToLeft(true)
+	table.Render()
+	want = "+------+-----------+\n" +
+		"| TIME | CHECK     |\n" +
+		"+------+-----------+\n" +
+		"|    1 |  disk   ✓ |\n" +
+		"|    1 |   net   ✗ |\n" +
+		"+------+-----------+\n" +
+		"|  2   | TOTAL   # |\n" +
+		"+------+-----------+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func BenchmarkRender(b *testing.B) {
+	table := NewWriter(ioutil.Discard)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	for i := 0; i < 1000; i++ {
+		table.Append([]string{"A", "The Good", strconv.Itoa(i)})
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		table.Render()
+	}
+}
+
+func TestRenderChunks(t *testing.T) {
+	data := [][]string{
+		{"1", "short"},
+		{"2", "a somewhat longer value that wraps"},
+		{"3", "x"},
+		{"4", "last"},
+	}
+	newTable := func(buf *bytes.Buffer) *Table {
+		table := NewWriter(buf)
+		table.SetHeader([]string{"Id", "Value"})
+		table.SetFooter([]string{"", "4 rows"})
+		table.SetColWidth(12)
+		table.SetRowLine(true)
+		table.SetColorMode(ColorAlways)
+		table.SetColumnHeatMap(0)
+		return table
+	}
+
+	var want bytes.Buffer
+	table := newTable(&want)
+	table.AppendBulk(data)
+	table.Render()
+
+	var got bytes.Buffer
+	table = newTable(&got)
+	calls := 0
+	err := table.RenderChunks(3, func(emit func(row []string)) {
+		calls++
+		for _, row := range data {
+			emit(row)
+		}
+	})
+	checkEqual(t, err, nil)
+	checkEqual(t, calls, 2)
+	checkEqual(t, got.String(), want.String())
+	checkEqual(t, table.NumLines(), 0)
+
+	// Row filters and numeric alignment apply as in Render.
+	filtered := func(table *Table) {
+		table.SetRowFilter(func(cells []string, idx int) bool { return idx != 1 })
+		table.SetNumericColumnAlignment(true, true, true)
+	}
+	want.Reset()
+	table = newTable(&want)
+	filtered(table)
+	table.AppendBulk(data)
+	table.Render()
+	got.Reset()
+	table = newTable(&got)
+	filtered(table)
+	err = table.RenderChunks(1, func(emit func(row []string)) {
+		for _, row := range data {
+			emit(row)
+		}
+	})
+	checkEqual(t, err, nil)
+	checkEqual(t, got.String(), want.String())
+
+	table.Append(data[0])
+	if err := table.RenderChunks(1, func(emit func(row []string)) {}); err == nil {
+		t.Error("expected error for a table with rows")
+	}
+}
+
+func TestSetParallelPrepare(t *testing.T) {
+	var data [][]string
+	for i := 0; i < 50; i++ {
+		data = append(data, []string{strconv.Itoa(i), strings.Repeat("word ", i%7), "1,000"})
+	}
+	render := func(workers int) string {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Id", "Text", "Count"})
+		table.SetColWidth(12)
+		table.SetParallelPrepare(workers)
+		table.SetRowTransform(func(i int, row []string) []string {
+			return append(row, strconv.Itoa(i))
+		})
+		table.AppendBulk(data)
+		table.Render()
+		return buf.String()
+	}
+	checkEqual(t, render(4), render(0))
+}
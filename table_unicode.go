@@ -1,6 +1,9 @@
 package tablewriter
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type UnicodeLineStyle int
 
@@ -18,8 +21,36 @@ const (
 	symsTR = "━│┍┑┕┙┝┥┯┷┿"
 	symsRD = "─║╓╖╙╜╟╢╥╨╫"
 	symsDR = "═│╒╕╘╛╞╡╤╧╪"
+	symsRC = "─│╭╮╰╯├┤┬┴┼"
+	symsLD = "┄┆┌┐└┘├┤┬┴┼"
+)
+
+// Symbol sets for SetSymbols
+const (
+	SymbolsASCII            = "ascii"
+	SymbolsLight            = "light"
+	SymbolsHeavy            = "heavy"
+	SymbolsDouble           = "double"
+	SymbolsRounded          = "rounded"
+	SymbolsDashed           = "dashed"
+	SymbolsDoubleHorizontal = "double-horizontal"
+	SymbolsDoubleVertical   = "double-vertical"
+	SymbolsHeavyHorizontal  = "heavy-horizontal"
+	SymbolsHeavyVertical    = "heavy-vertical"
 )
 
+var symbolSets = map[string]string{
+	SymbolsLight:            symsRR,
+	SymbolsHeavy:            symsTT,
+	SymbolsDouble:           symsDD,
+	SymbolsRounded:          symsRC,
+	SymbolsDashed:           symsLD,
+	SymbolsDoubleHorizontal: symsDR,
+	SymbolsDoubleVertical:   symsRD,
+	SymbolsHeavyHorizontal:  symsTR,
+	SymbolsHeavyVertical:    symsRT,
+}
+
 func simpleSyms(center, row, column string) []string {
 	return []string{row, column, center, center, center, center, center, center, center, center, center}
 }
@@ -47,9 +78,71 @@ func (t *Table) SetUnicodeHV(horizontal, vertical UnicodeLineStyle) error {
 	default:
 		return errors.New("Unsupported combination of unicode line styles")
 	}
+	t.setSyms(syms)
+	return nil
+}
+
+// SetSymbols Use a named set of line drawing symbols
+// name is one of the Symbols constants, e.g. SymbolsRounded for light lines
+// with rounded corners. SymbolsASCII restores the separators set by
+// SetCenterSeparator, SetRowSeparator and SetColumnSeparator.
+func (t *Table) SetSymbols(name string) error {
+	if name == SymbolsASCII {
+		t.syms = simpleSyms(t.pCenter, t.pRow, t.pColumn)
+		return nil
+	}
+	syms, ok := symbolSets[name]
+	if !ok {
+		return fmt.Errorf("unknown symbol set %q", name)
+	}
+	t.setSyms(syms)
+	return nil
+}
+
+// SetSectionSymbols Use a named set of symbols for the lines of a section
+// section is SectionHeader for the line below the header, SectionRow for
+// the lines between rows and SectionFooter for the line above the footer.
+// Pick a set whose vertical lines match the table's, e.g.
+// SymbolsHeavyHorizontal below a header of a light table.
+func (t *Table) SetSectionSymbols(section int, name string) error {
+	saved := t.syms
+	defer func() { t.syms = saved }()
+	if err := t.SetSymbols(name); err != nil {
+		return err
+	}
+	t.sectionSyms[section] = t.syms
+	return nil
+}
+
+func (t *Table) setSyms(syms string) {
 	t.syms = make([]string, 0, 11)
 	for _, sym := range []rune(syms) {
 		t.syms = append(t.syms, string(sym))
 	}
-	return nil
+}
+
+// sectionLine calls print with the symbols of section in effect.
+func (t *Table) sectionLine(section int, print func()) {
+	syms, ok := t.sectionSyms[section]
+	if !ok {
+		print()
+		return
+	}
+	saved := t.syms
+	t.syms = syms
+	print()
+	t.syms = saved
+}
+
+// printRowLine prints the line below a data row, last tells whether the
+// row is the last one printed.
+func (t *Table) printRowLine(last bool) {
+	switch {
+	case !last:
+		t.sectionLine(SectionRow, func() { t.printLine(false, false) })
+	case len(t.footers) > 0:
+		t.sectionLine(SectionFooter, func() { t.printLine(false, false) })
+	default:
+		t.printLine(false, true)
+	}
 }
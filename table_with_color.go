@@ -2,6 +2,9 @@ package tablewriter
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -62,6 +65,75 @@ const (
 
 type Colors []int
 
+// ColorMode controls whether color attributes are written to the output.
+type ColorMode int
+
+const (
+	// ColorAlways always writes color escape sequences. This is the default.
+	ColorAlways ColorMode = iota
+	// ColorAuto writes colors only when the NO_COLOR environment variable
+	// is empty and the table writes to a terminal.
+	ColorAuto
+	// ColorNever never writes color escape sequences.
+	ColorNever
+)
+
+// SetColorMode Set when color attributes are applied
+func (t *Table) SetColorMode(mode ColorMode) {
+	t.colorMode = mode
+}
+
+// colorEnabled reports whether colors should be written for the current output.
+func (t *Table) colorEnabled() bool {
+	if t.colorCached {
+		return t.colorOn
+	}
+	switch t.colorMode {
+	case ColorNever:
+		return false
+	case ColorAuto:
+		if os.Getenv("NO_COLOR") != "" {
+			return false
+		}
+		return isTerminal(t.terminal())
+	}
+	return true
+}
+
+// cacheColor makes colorEnabled report the same for a whole render without
+// checking the output again, returning a function ending it.
+func (t *Table) cacheColor() func() {
+	if t.colorCached {
+		return func() {}
+	}
+	t.colorOn = t.colorEnabled()
+	t.colorCached = true
+	return func() { t.colorCached = false }
+}
+
+// terminal returns the writer whose terminal, if any, decides the colors
+// and width of the table, which is the output unless the table is rendered
+// to a buffer on its behalf.
+func (t *Table) terminal() io.Writer {
+	if t.term != nil {
+		return t.term
+	}
+	return t.out
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
 func startFormat(seq string) string {
 	return fmt.Sprintf("%s[%sm", ESC, seq)
 }
@@ -134,3 +206,139 @@ func (t *Table) SetFooterColor(colors ...Colors) {
 func Color(colors ...int) []int {
 	return colors
 }
+
+// SetRowStripes Color odd and even rows alternately
+// Rows are counted from 1 in the order they are printed, so multi-line
+// rows are striped as a whole. Background colors such as BgBlackColor
+// suit stripes best; an empty Colors leaves the rows plain.
+func (t *Table) SetRowStripes(odd, even Colors) {
+	t.stripes = [2]string{makeSequence(odd), makeSequence(even)}
+}
+
+// stripe formats cell with the stripe of the data row at position
+// t.rowPos; other rows are left plain.
+func (t *Table) stripe(cell string, rowIdx int) string {
+	params := t.stripes[t.rowPos%2]
+	if rowIdx < 0 || params == "" || !t.colorEnabled() {
+		return cell
+	}
+	// Restore the stripe after the resets of colored text.
+	cell = strings.Replace(cell, stopFormat(), stopFormat()+startFormat(params), -1)
+	return format(cell, params)
+}
+
+// SetColumnHeatMap Color the numbers of a column by their value
+// The colors run from the lowest to the highest number of the column, e.g.
+// Colors{BgGreenColor}, Colors{BgYellowColor}, Colors{BgRedColor}, which
+// is the default when no colors are given. Cells that are not numbers and
+// cells with colors of their own are left as they are.
+func (t *Table) SetColumnHeatMap(column int, colors ...Colors) {
+	if len(colors) == 0 {
+		colors = []Colors{{BgGreenColor}, {BgYellowColor}, {BgRedColor}}
+	}
+	t.heatMaps[column] = colors
+}
+
+// prepareHeatMaps records the lowest and highest numbers of the shown rows
+// of every heat map column.
+func (t *Table) prepareHeatMaps() {
+	t.startHeatRanges()
+	if len(t.heatMaps) == 0 {
+		return
+	}
+	for _, i := range t.visibleRows() {
+		t.widenHeatRanges(t.rows[i])
+	}
+}
+
+// startHeatRanges sets the range of every heat map column to an empty one.
+func (t *Table) startHeatRanges() {
+	t.heatRanges = make(map[int][2]float64)
+	for y := range t.heatMaps {
+		t.heatRanges[y] = [2]float64{math.Inf(1), math.Inf(-1)}
+	}
+}
+
+// widenHeatRanges widens the ranges of the heat map columns to the numbers
+// in the cells of row.
+func (t *Table) widenHeatRanges(row []string) {
+	for y, r := range t.heatRanges {
+		if n, ok := cellNumber(t.cellOf(row, y)); ok {
+			t.heatRanges[y] = [2]float64{math.Min(r[0], n), math.Max(r[1], n)}
+		}
+	}
+}
+
+// heatColors returns the heat map colors of column y in row rowIdx, or nil
+// if the cell is not colored by a heat map.
+func (t *Table) heatColors(rowIdx, y int) Colors {
+	colors := t.heatMaps[y]
+	r, ok := t.heatRanges[y]
+	if !ok || rowIdx < 0 || rowIdx >= len(t.rows) || len(colors) == 0 {
+		return nil
+	}
+	n, ok := cellNumber(t.cellOf(t.rows[rowIdx], y))
+	if !ok {
+		return nil
+	}
+	if r[1] <= r[0] {
+		return colors[0]
+	}
+	// Cells changed or filtered out since the range was taken may lie
+	// outside of it.
+	i := int((n-r[0])/(r[1]-r[0])*float64(len(colors)-1) + 0.5)
+	if i < 0 {
+		i = 0
+	} else if i >= len(colors) {
+		i = len(colors) - 1
+	}
+	return colors[i]
+}
+
+// cellNumber parses a cell holding a number, possibly with thousands
+// separators or a percent sign.
+func cellNumber(s string) (float64, bool) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	if !decimal.MatchString(s) {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(strings.Replace(s, ",", "", -1), 64)
+	return n, err == nil
+}
+
+// SetBorderColor Color the borders and separators of the table
+// The lines are colored apart from the cells, e.g. Colors{FgHiBlackColor}
+// for a dim frame around plain text. An empty Colors leaves them plain.
+func (t *Table) SetBorderColor(colors Colors) {
+	t.borderParams = makeSequence(colors)
+}
+
+// border formats the runs of border characters in s with the border
+// color, coloring each run once rather than every symbol.
+func (t *Table) border(s string) string {
+	if t.borderParams == "" || !t.colorEnabled() {
+		return s
+	}
+	var b strings.Builder
+	start := -1
+	for i, r := range s {
+		switch {
+		case r == ' ' && start >= 0:
+			b.WriteString(format(s[start:i], t.borderParams))
+			start = -1
+		case r == ' ':
+			b.WriteRune(r)
+			continue
+		case start < 0:
+			start = i
+			continue
+		default:
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if start >= 0 {
+		b.WriteString(format(s[start:], t.borderParams))
+	}
+	return b.String()
+}
@@ -0,0 +1,112 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package tabletest helps testing the output of tables against golden
+// files.
+//
+// Golden files live in the testdata directory of the package under test
+// and are written by running the tests with the -tabletest.update flag.
+package tabletest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+var update = flag.Bool("tabletest.update", false, "write golden files instead of comparing with them")
+
+// Render renders a copy of table without colors and ignoring the terminal
+// width, so that the output does not depend on where the tests run, and
+// returns it normalized.
+func Render(table *tablewriter.Table) string {
+	table = table.Clone(ioutil.Discard, true)
+	table.SetColorMode(tablewriter.ColorNever)
+	table.SetAutoTerminalWidth(false)
+	s, _ := table.RenderString()
+	return Normalize(s)
+}
+
+// Normalize removes the white space ending the lines of s and ends s with
+// a single newline, so that editors trimming white space do not break
+// golden files. Line endings are made "\n".
+func Normalize(s string) string {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// Golden compares the rendered table with testdata/name.golden, reporting
+// the lines that differ.
+func Golden(t testing.TB, name string, table *tablewriter.Table) {
+	t.Helper()
+	GoldenString(t, name, Render(table))
+}
+
+// GoldenString compares got with testdata/name.golden, reporting the lines
+// that differ. Both are normalized first. With -tabletest.update the file
+// is written with got normalized instead.
+func GoldenString(t testing.TB, name, got string) {
+	t.Helper()
+	got = Normalize(got)
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -tabletest.update to create it)", err)
+	}
+	if diff := Diff(Normalize(string(want)), got); diff != "" {
+		t.Errorf("%s differs from the golden file (-want +got):\n%s", path, diff)
+	}
+}
+
+// Diff returns the lines of want and got that differ, prefixed with "-"
+// and "+" respectively and with their line numbers, or an empty string
+// when both are equal.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	w, g := strings.Split(want, "\n"), strings.Split(got, "\n")
+	n := len(w)
+	if len(g) > n {
+		n = len(g)
+	}
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		var wl, gl string
+		if i < len(w) {
+			wl = w[i]
+		}
+		if i < len(g) {
+			gl = g[i]
+		}
+		if wl == gl && i < len(w) && i < len(g) {
+			continue
+		}
+		if i < len(w) {
+			fmt.Fprintf(&b, "%4d - %s\n", i+1, wl)
+		}
+		if i < len(g) {
+			fmt.Fprintf(&b, "%4d + %s\n", i+1, gl)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package tabletest
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+func TestGolden(t *testing.T) {
+	table := tablewriter.NewWriter(ioutil.Discard)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.SetHeaderColor(tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{tablewriter.Bold})
+	table.SetBorder(false)
+	table.Append([]string{"A", "The Good"})
+	table.Append([]string{"B", "The Very very Bad Man"})
+	Golden(t, "simple", table)
+
+	// Line endings and trailing white space of got don't matter either.
+	got := strings.Replace(Render(table), "\n", " \r\n", -1)
+	GoldenString(t, "simple", got)
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("a  \r\nb\t\n\n"); got != "a\nb\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	if diff := Diff("a\nb\n", "a\nb\n"); diff != "" {
+		t.Errorf("unexpected diff %q", diff)
+	}
+	want := "   2 - b\n   2 + c\n"
+	if diff := Diff("a\nb\n", "a\nc\n"); diff != want {
+		t.Errorf("got %q, want %q", diff, want)
+	}
+}
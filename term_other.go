@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package tablewriter
+
+import "io"
+
+// terminalWidth is not supported on this platform and always returns 0.
+func terminalWidth(w io.Writer) int {
+	return 0
+}
@@ -0,0 +1,119 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "strings"
+
+const (
+	treeBranch = "├── "
+	treeLast   = "└── "
+	treePipe   = "│   "
+	treeSpace  = "    "
+)
+
+// SetTreeColumn Draw the rows appended with AppendTree as a tree
+// The cells of column are indented by their depth with branch lines drawn
+// from each row to its parent, the closest row above of lower depth. The
+// lines are drawn between the rows as printed, after SortBy and
+// SetRowFilter, so a row whose parent is left out hangs from the closest
+// printed row above it of lower depth. A negative column disables the tree.
+func (t *Table) SetTreeColumn(column int) {
+	t.treeCol = column
+}
+
+// AppendTree Append row at depth in the tree of SetTreeColumn
+// Rows at depth 0 are roots; other rows are children of the closest row
+// above them of lower depth.
+func (t *Table) AppendTree(depth int, row []string) {
+	defer t.lock()()
+	n := len(t.lines)
+	if depth > 0 {
+		t.treeDepths[n] = depth
+	}
+	if !t.append(row) {
+		delete(t.treeDepths, n)
+	}
+}
+
+// treeIndent returns the width taken by the tree lines of row rowIdx.
+func (t *Table) treeIndent(rowIdx int) int {
+	return t.treeDepths[rowIdx] * DisplayWidth(treeBranch)
+}
+
+// prepareTree works out the tree lines of every printed row, in the order
+// rows are printed, and widens the tree column to fit them.
+func (t *Table) prepareTree() {
+	t.treePrefixes = make(map[int][2]string)
+	if t.treeCol < 0 || len(t.treeDepths) == 0 {
+		return
+	}
+	// open[d] is set while the last row seen at depth d has siblings below.
+	var open []bool
+	order := t.visibleRows()
+	for p, i := range order {
+		depth := t.treeDepths[i]
+		if depth == 0 {
+			open = open[:0]
+			continue
+		}
+		last := true
+		for _, j := range order[p+1:] {
+			if d := t.treeDepths[j]; d <= depth {
+				last = d < depth
+				break
+			}
+		}
+		for len(open) <= depth {
+			open = append(open, false)
+		}
+		open = open[:depth+1]
+		open[depth] = !last
+
+		var b strings.Builder
+		for d := 1; d < depth; d++ {
+			b.WriteString(ConditionString(open[d], treePipe, treeSpace))
+		}
+		ancestors := b.String()
+		t.treePrefixes[i] = [2]string{
+			ancestors + ConditionString(last, treeLast, treeBranch),
+			ancestors + ConditionString(last, treeSpace, treePipe),
+		}
+
+		if t.treeCol < len(t.lines[i]) {
+			for _, line := range t.lines[i][t.treeCol] {
+				if w := DisplayWidth(line) + t.treeIndent(i); w > t.cs[t.treeCol] {
+					t.cs[t.treeCol] = w
+				}
+			}
+		}
+	}
+}
+
+// treeColumns returns columns with the tree lines of row rowIdx added to
+// the tree column.
+func (t *Table) treeColumns(columns [][]string, rowIdx int) [][]string {
+	prefix, ok := t.treePrefixes[rowIdx]
+	if !ok || t.treeCol >= len(columns) {
+		return columns
+	}
+	lines := make([]string, t.rs[rowIdx])
+	for x := range lines {
+		line := ""
+		if x < len(columns[t.treeCol]) {
+			line = columns[t.treeCol][x]
+		}
+		lines[x] = prefix[1] + line
+		if x == 0 {
+			lines[x] = prefix[0] + line
+		}
+	}
+	out := make([][]string, len(columns))
+	copy(out, columns)
+	out[t.treeCol] = lines
+	return out
+}
@@ -0,0 +1,70 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package tablewriter
+
+import "io"
+
+// Column extracts one column of a Typed table from a value.
+type Column[T any] struct {
+	Header string
+	Value  func(T) string
+}
+
+// Typed is a table whose rows are values of T, turned into cells by its
+// columns without reflection. All other settings are those of Table.
+type Typed[T any] struct {
+	*Table
+	columns []Column[T]
+}
+
+// NewTyped Start a new table of values of T with the given columns
+// The header is set from the column headers unless all of them are empty.
+func NewTyped[T any](writer io.Writer, columns ...Column[T]) *Typed[T] {
+	t := &Typed[T]{Table: NewWriter(writer), columns: columns}
+	headers := make([]string, len(columns))
+	hasHeader := false
+	for i, c := range columns {
+		headers[i] = c.Header
+		hasHeader = hasHeader || c.Header != ""
+	}
+	if hasHeader {
+		t.SetHeader(headers)
+	}
+	return t
+}
+
+// Append Append v as a row
+func (t *Typed[T]) Append(v T) {
+	row := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		row[i] = c.Value(v)
+	}
+	t.Table.Append(row)
+}
+
+// AppendBulk Append every value of vs as a row
+func (t *Typed[T]) AppendBulk(vs []T) {
+	for _, v := range vs {
+		t.Append(v)
+	}
+}
+
+// AppendValues Append a row extracted from every value of rows
+// Unlike SetStructs and AppendAny, it uses no reflection or formatting of
+// values, and the table is locked and grown once for all the rows, which
+// suits appending a great many rows.
+func AppendValues[T any](t *Table, rows []T, extract func(T) []string) {
+	defer t.lock()()
+	if n := len(t.lines) + len(rows); n > cap(t.lines) {
+		t.lines = append(make([][][]string, 0, n), t.lines...)
+		t.rows = append(make([][]string, 0, n), t.rows...)
+	}
+	for _, v := range rows {
+		t.append(extract(v))
+	}
+}
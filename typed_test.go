@@ -0,0 +1,64 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package tablewriter
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestNewTyped(t *testing.T) {
+	type mascot struct {
+		Name string
+		Age  int
+	}
+
+	var buf bytes.Buffer
+	table := NewTyped(&buf,
+		Column[mascot]{Header: "Name", Value: func(m mascot) string { return m.Name }},
+		Column[mascot]{Header: "Age", Value: func(m mascot) string { return strconv.Itoa(m.Age) }},
+	)
+	table.Append(mascot{"Gopher", 13})
+	table.AppendBulk([]mascot{{"Ferris", 9}})
+	table.SetBorder(false)
+	table.Render()
+
+	want := `   NAME  | AGE  
+---------+------
+  Gopher |  13  
+  Ferris |   9  
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestAppendValues(t *testing.T) {
+	type mascot struct {
+		Name string
+		Age  int
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Age"})
+	table.Append([]string{"Gopher", "13"})
+	AppendValues(table, []mascot{{"Ferris", 9}, {"Duke", 29}}, func(m mascot) []string {
+		return []string{m.Name, strconv.Itoa(m.Age)}
+	})
+	table.Render()
+
+	want := `+--------+-----+
+|  NAME  | AGE |
++--------+-----+
+| Gopher |  13 |
+| Ferris |   9 |
+| Duke   |  29 |
++--------+-----+
+`
+	checkEqual(t, buf.String(), want)
+}
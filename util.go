@@ -11,14 +11,137 @@ import (
 	"math"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
 )
 
 var ansi = regexp.MustCompile("\033\\[(?:[0-9]{1,3}(?:;[0-9]{1,3})*)?[m|K]")
 
+// bidi matches the invisible marks and isolates of bidirectional text.
+var bidi = regexp.MustCompile("[\u061C\u200E\u200F\u202A-\u202E\u2066-\u2069]")
+
+var plainNumber = regexp.MustCompile(`^([-+]?)(\d+)(?:\.(\d+))?$`)
+
+// DisplayWidth returns the display width of str without its escape
+// sequences and bidirectional marks.
 func DisplayWidth(str string) int {
-	return runewidth.StringWidth(ansi.ReplaceAllLiteralString(str, ""))
+	if isPrintableASCII(str) {
+		return len(str)
+	}
+	if strings.Contains(str, ESC) {
+		str = ansi.ReplaceAllLiteralString(str, "")
+	}
+	return stringWidth(bidi.ReplaceAllLiteralString(str, ""))
+}
+
+// stringWidth returns the number of cells str takes, counting every grapheme
+// cluster, such as an emoji ZWJ sequence or a letter with combining marks,
+// as a single character.
+func stringWidth(str string) int {
+	if isPrintableASCII(str) {
+		return len(str)
+	}
+	width := 0
+	g := uniseg.NewGraphemes(str)
+	for g.Next() {
+		width += clusterWidth(g.Runes())
+	}
+	return width
+}
+
+// isPrintableASCII reports whether str holds only printable ASCII
+// characters, each a cell wide, so that it can be measured without
+// splitting it into grapheme clusters.
+func isPrintableASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] < ' ' || str[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// clusterWidth returns the width of a grapheme cluster, that of its first
+// rune taking space unless it is an emoji presentation sequence or a flag.
+func clusterWidth(cluster []rune) int {
+	for _, r := range cluster[1:] {
+		if r == '\uFE0F' {
+			return 2
+		}
+	}
+	if len(cluster) == 2 && isRegionalIndicator(cluster[0]) && isRegionalIndicator(cluster[1]) {
+		return 2
+	}
+	for _, r := range cluster {
+		if w := runewidth.RuneWidth(r); w > 0 {
+			return w
+		}
+	}
+	return 0
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// cutWidth returns the longest prefix of str no wider than width cells,
+// without splitting grapheme clusters.
+func cutWidth(str string, width int) string {
+	w := 0
+	g := uniseg.NewGraphemes(str)
+	for g.Next() {
+		if w += clusterWidth(g.Runes()); w > width {
+			from, _ := g.Positions()
+			return str[:from]
+		}
+	}
+	return str
+}
+
+// cutWidthLeft returns the longest suffix of str no wider than width cells,
+// without splitting grapheme clusters.
+func cutWidthLeft(str string, width int) string {
+	clusters := graphemes(str)
+	w, from := 0, len(str)
+	for i := len(clusters) - 1; i >= 0; i-- {
+		if w += DisplayWidth(clusters[i]); w > width {
+			break
+		}
+		from -= len(clusters[i])
+	}
+	return str[from:]
+}
+
+// expandTabs replaces the tabs of line with spaces up to the next multiple
+// of width cells.
+func expandTabs(line string, width int) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var b strings.Builder
+	col := 0
+	for i, part := range strings.Split(line, "\t") {
+		if i > 0 {
+			n := width - col%width
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		}
+		b.WriteString(part)
+		col += DisplayWidth(part)
+	}
+	return b.String()
+}
+
+// graphemes splits str into its grapheme clusters.
+func graphemes(str string) []string {
+	var clusters []string
+	g := uniseg.NewGraphemes(str)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	return clusters
 }
 
 // ConditionString Simple Condition for string
@@ -91,3 +214,89 @@ func PadLeft(s, pad string, width int) string {
 	}
 	return s
 }
+
+// StripControl removes terminal control sequences from str so that untrusted
+// data can't change the window title, move the cursor or write to the
+// clipboard. SGR color sequences are kept as they only affect styling.
+func StripControl(str string) string {
+	var b strings.Builder
+	b.Grow(len(str))
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		switch {
+		case r == '\033':
+			i += size + skipEscape(str, i, &b)
+			continue
+		case r == '\n' || r == '\t':
+			b.WriteRune(r)
+		case r < 0x20 || (r >= 0x7f && r <= 0x9f):
+			// Drop C0 and C1 control characters
+		case r == utf8.RuneError && size == 1 && str[i] >= 0x80 && str[i] <= 0x9f:
+			// Drop raw 8-bit C1 control bytes
+		default:
+			b.WriteString(str[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// skipEscape returns the length of the escape sequence following the ESC
+// at str[start], copying it to b when it is an SGR sequence.
+func skipEscape(str string, start int, b *strings.Builder) int {
+	rest := str[start+1:]
+	if len(rest) == 0 {
+		return 0
+	}
+	switch rest[0] {
+	case '[':
+		// CSI: parameter and intermediate bytes followed by a final byte
+		for j := 1; j < len(rest); j++ {
+			if c := rest[j]; c >= 0x40 && c <= 0x7e {
+				if c == 'm' {
+					b.WriteString(str[start : start+j+2])
+				}
+				return j + 1
+			}
+		}
+		return len(rest)
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM and APC run until BEL or ST
+		for j := 1; j < len(rest); j++ {
+			if rest[j] == '\a' {
+				return j + 1
+			}
+			if rest[j] == '\033' && j+1 < len(rest) && rest[j+1] == '\\' {
+				return j + 2
+			}
+		}
+		return len(rest)
+	}
+	_, size := utf8.DecodeRuneInString(rest)
+	return size
+}
+
+// GroupDigits Insert thousands separators into a plain number
+// The integer digits of str are grouped by three with thousands and the
+// decimal point is replaced by decimal, e.g. GroupDigits("1234567.5", ".", ",")
+// returns "1.234.567,5". Strings that are not plain numbers are returned as is.
+func GroupDigits(str, thousands, decimal string) string {
+	m := plainNumber.FindStringSubmatch(str)
+	if m == nil {
+		return str
+	}
+	digits := m[2]
+	var b strings.Builder
+	b.WriteString(m[1])
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+	if m[3] != "" {
+		b.WriteString(decimal)
+		b.WriteString(m[3])
+	}
+	return b.String()
+}
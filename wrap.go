@@ -11,8 +11,6 @@ import (
 	"math"
 	"strings"
 	"unicode"
-
-	"github.com/mattn/go-runewidth"
 )
 
 const (
@@ -35,7 +33,7 @@ func WrapString(s string, lim int) ([]string, int) {
 	var lines []string
 	max := 0
 	for _, v := range words {
-		max = runewidth.StringWidth(v)
+		max = stringWidth(v)
 		if max > lim {
 			lim = max
 		}
@@ -87,7 +85,7 @@ func WrapWords(words []string, spc, lim, pen int) [][]string {
 	}
 	lengths := make([]int, n)
 	for i := 0; i < n; i++ {
-		lengths[i] = runewidth.StringWidth(words[i])
+		lengths[i] = stringWidth(words[i])
 	}
 	nbrk := make([]int, n)
 	cost := make([]int, n)
@@ -129,7 +127,47 @@ func WrapWords(words []string, spc, lim, pen int) [][]string {
 	return lines
 }
 
-// getLines decomposes a multiline string into a slice of strings.
+// splitLines breaks every line wider than lim into chunks of at most lim
+// display cells.
+func splitLines(lines []string, lim int) []string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		for DisplayWidth(line) > lim {
+			chunk := cutWidth(line, lim)
+			if chunk == "" {
+				// A single character wider than lim
+				chunk = graphemes(line)[0]
+			}
+			out = append(out, chunk)
+			line = line[len(chunk):]
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// truncate shortens s to at most lim display cells by cutting it at
+// position, one of the TRUNCATE constants, marking the cut with tail when
+// there is room for it.
+func truncate(s string, lim int, tail string, position int) string {
+	if DisplayWidth(s) <= lim {
+		return s
+	}
+	if DisplayWidth(tail) >= lim {
+		tail = ""
+	}
+	room := lim - DisplayWidth(tail)
+	switch position {
+	case TRUNCATE_START:
+		return tail + cutWidthLeft(s, room)
+	case TRUNCATE_MIDDLE:
+		return cutWidth(s, room-room/2) + tail + cutWidthLeft(s, room/2)
+	}
+	return cutWidth(s, room) + tail
+}
+
+// getLines decomposes a multiline string into a slice of strings. Lines
+// may end with either "\n" or "\r\n".
 func getLines(s string) []string {
-	return strings.Split(s, nl)
+	return strings.Split(strings.Replace(s, "\r\n", nl, -1), nl)
 }
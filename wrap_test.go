@@ -60,6 +60,33 @@ func TestDisplayWidth(t *testing.T) {
 	checkEqual(t, DisplayWidth(input), want)
 }
 
+func TestDisplayWidthGraphemes(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want int
+	}{
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466", 2}, // family ZWJ sequence
+		{"e\u0301", 1},              // combining acute accent
+		{"\U0001F1EF\U0001F1F5", 2}, // flag
+		{"\u2764\uFE0F", 2},         // emoji presentation
+		{"\u200Fab", 2},             // right-to-left mark
+		{"plain text", 10},
+		{"\033[31mred\033[0m", 3},
+		{"a\tb", 2}, // tab has no width
+	} {
+		checkEqual(t, DisplayWidth(tt.in), tt.want, tt.in)
+	}
+}
+
+func TestTruncateGraphemes(t *testing.T) {
+	family := "\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466"
+	checkEqual(t, truncate(family+family, 3, "", TRUNCATE_END), family)
+	checkEqual(t, truncate("ae\u0301bc", 3, ".", TRUNCATE_END), "ae\u0301.")
+	checkEqual(t, truncate("ae\u0301bc", 3, ".", TRUNCATE_START), ".bc")
+	checkEqual(t, truncate("abcdef", 5, "..", TRUNCATE_MIDDLE), "ab..f")
+	checkEqual(t, splitLines([]string{family + "x"}, 1), []string{family, "x"})
+}
+
 // WrapString was extremely memory greedy, it performed insane number of
 // allocations for what it was doing. See BenchmarkWrapString for details.
 func TestWrapStringAllocation(t *testing.T) {
@@ -0,0 +1,196 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxStyleNormal = 0
+	xlsxStyleBold   = 1
+)
+
+var xlsxParts = []struct {
+	name, body string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`},
+}
+
+// WriteXLSX writes the table as an Excel workbook with a single sheet.
+// Headers and footers are bolded, numbers are stored as numeric cells but
+// for those Excel would change, such as "007", identical cells merged by
+// SetAutoMergeCells are merged in the sheet and column widths follow the
+// widths of the rendered table.
+func (t *Table) WriteXLSX(writer io.Writer) error {
+	zw := zip.NewWriter(writer)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(t.xlsxSheet()); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// xlsxSheet builds the worksheet part of the workbook.
+func (t *Table) xlsxSheet() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(t.cs) > 0 {
+		buf.WriteString("<cols>")
+		for i := 0; i < len(t.cs); i++ {
+			fmt.Fprintf(&buf, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, t.cs[i]+2)
+		}
+		buf.WriteString("</cols>")
+	}
+
+	buf.WriteString("<sheetData>")
+	r := 0
+	if len(t.rawHeaders) > 0 {
+		r++
+		t.xlsxRow(&buf, r, t.formatKeys(t.rawHeaders), xlsxStyleBold)
+	}
+	first := r + 1
+	rows := make([][]string, 0, len(t.rows))
+	for _, i := range t.visibleRows() {
+		rows = append(rows, t.rows[i])
+	}
+	for _, row := range rows {
+		r++
+		t.xlsxRow(&buf, r, row, xlsxStyleNormal)
+	}
+	if len(t.rawFooters) > 0 {
+		r++
+		t.xlsxRow(&buf, r, t.formatKeys(t.rawFooters), xlsxStyleBold)
+	}
+	buf.WriteString("</sheetData>")
+
+	if merges := t.xlsxMerges(rows, first); len(merges) > 0 {
+		fmt.Fprintf(&buf, `<mergeCells count="%d">`, len(merges))
+		for _, ref := range merges {
+			fmt.Fprintf(&buf, `<mergeCell ref="%s"/>`, ref)
+		}
+		buf.WriteString("</mergeCells>")
+	}
+
+	buf.WriteString("</worksheet>")
+	return buf.Bytes()
+}
+
+// xlsxRow writes a single sheet row numbered r.
+func (t *Table) xlsxRow(buf *bytes.Buffer, r int, cells []string, style int) {
+	fmt.Fprintf(buf, `<row r="%d">`, r)
+	for i, v := range cells {
+		v = ansi.ReplaceAllLiteralString(v, "")
+		ref := xlsxColumn(i) + strconv.Itoa(r)
+		if n, ok := xlsxNumber(v); ok && style == xlsxStyleNormal {
+			fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, n)
+			continue
+		}
+		fmt.Fprintf(buf, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+		xml.EscapeText(buf, []byte(v))
+		buf.WriteString("</t></is></c>")
+	}
+	buf.WriteString("</row>")
+}
+
+// xlsxNumber returns cell v as the value of a numeric cell, if it is a
+// number Excel keeps as written: codes with leading zeros such as "007" and
+// numbers of more than 15 significant digits, which Excel rounds, are not.
+func xlsxNumber(v string) (string, bool) {
+	n := strings.TrimSpace(v)
+	if !decimal.MatchString(n) {
+		return "", false
+	}
+	n = strings.Replace(n, ",", "", -1)
+	whole := strings.SplitN(strings.TrimPrefix(n, "-"), ".", 2)[0]
+	if len(whole) > 1 && whole[0] == '0' {
+		return "", false
+	}
+	digits := strings.Replace(strings.TrimPrefix(n, "-"), ".", "", 1)
+	if len(strings.TrimLeft(digits, "0")) > 15 {
+		return "", false
+	}
+	return n, true
+}
+
+// xlsxMerges returns the cell ranges of vertically merged data cells of
+// rows, given the sheet row of the first data row.
+func (t *Table) xlsxMerges(rows [][]string, first int) []string {
+	if !t.autoMergeCells {
+		return nil
+	}
+	var refs []string
+	for y := 0; y < len(t.cs); y++ {
+		if !t.isMergeColumn(y) {
+			continue
+		}
+		start := 0
+		for i := 1; i <= len(rows); i++ {
+			if i < len(rows) && y < len(rows[i]) && y < len(rows[start]) &&
+				rows[i][y] != "" && rows[start][y] != "" && t.mergeEqual(y, rows[start][y], rows[i][y]) {
+				continue
+			}
+			if i-start > 1 {
+				refs = append(refs, fmt.Sprintf("%s%d:%s%d",
+					xlsxColumn(y), first+start, xlsxColumn(y), first+i-1))
+			}
+			start = i
+		}
+	}
+	return refs
+}
+
+// formatKeys returns header or footer keys as they appear when rendered.
+func (t *Table) formatKeys(keys []string) []string {
+	if !t.autoFmt {
+		return keys
+	}
+	out := make([]string, len(keys))
+	for i, v := range keys {
+		out[i] = Title(v)
+	}
+	return out
+}
+
+// xlsxColumn returns the spreadsheet column name for index i, e.g. A, Z, AA.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}